@@ -0,0 +1,111 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+// AmendOrderReq reduces the quantity of an open order keeping its priority in the order book.
+// Remark: Either OrderID or OrigClientOrderID must be set, NewQty must be lower than the current quantity
+type AmendOrderReq struct {
+	Symbol            string `url:"symbol"`
+	OrderID           uint64 `url:"orderId,omitempty"`
+	OrigClientOrderID string `url:"origClientOrderId,omitempty"`
+	NewClientOrderID  string `url:"newClientOrderId,omitempty"`
+	NewQty            string `url:"newQty"`
+}
+
+// AmendedOrder is the state of the order after the amendment
+type AmendedOrder struct {
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 uint64                  `json:"orderId"`
+	OrderListID             int64                   `json:"orderListId"`
+	OrigClientOrderID       string                  `json:"origClientOrderId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   decimal.Decimal         `json:"price"`
+	Qty                     decimal.Decimal         `json:"qty"`
+	ExecutedQty             decimal.Decimal         `json:"executedQty"`
+	PreventedQty            decimal.Decimal         `json:"preventedQty"`
+	QuoteOrderQty           decimal.Decimal         `json:"quoteOrderQty"`
+	CumulativeQuoteQty      decimal.Decimal         `json:"cumulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	WorkingTime             uint64                  `json:"workingTime"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode,omitempty"`
+}
+
+type AmendOrderResp struct {
+	TransactTime uint64       `json:"transactTime"`
+	ExecutionID  int64        `json:"executionId"`
+	AmendedOrder AmendedOrder `json:"amendedOrder"`
+	ListStatus   *OrderList   `json:"listStatus,omitempty"` // ListStatus is set when the order is a part of an order list
+}
+
+type OrderAmendmentsReq struct {
+	Symbol          string `url:"symbol"`
+	OrderID         uint64 `url:"orderId"`
+	FromExecutionID int64  `url:"fromExecutionId,omitempty"`
+	Limit           int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+type OrderAmendment struct {
+	Symbol            string          `json:"symbol"`
+	OrderID           uint64          `json:"orderId"`
+	ExecutionID       int64           `json:"executionId"`
+	OrigClientOrderID string          `json:"origClientOrderId"`
+	NewClientOrderID  string          `json:"newClientOrderId"`
+	OrigQty           decimal.Decimal `json:"origQty"`
+	NewQty            decimal.Decimal `json:"newQty"`
+	Time              uint64          `json:"time"`
+}
+
+// AmendOrderKeepPriority reduces the quantity of an open order without losing its queue priority
+func (c *Client) AmendOrderKeepPriority(req *AmendOrderReq) (*AmendOrderResp, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, ErrEmptyOrderID
+	}
+	if err := requireQuantity(req.NewQty); err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPut, EndpointOrderAmendKeepPriority, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AmendOrderResp{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// OrderAmendments get all amendments of a single order
+func (c *Client) OrderAmendments(req *OrderAmendmentsReq) ([]*OrderAmendment, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.OrderID == 0 {
+		return nil, ErrEmptyOrderID
+	}
+	if req.Limit <= 0 || req.Limit > MaxOrderLimit {
+		req.Limit = DefaultOrderLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrderAmendments, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OrderAmendment
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
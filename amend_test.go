@@ -0,0 +1,60 @@
+package binance_test
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) TestAmendOrderKeepPriority() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPut, method)
+		s.Require().Equal(binance.EndpointOrderAmendKeepPriority, endpoint)
+		s.Require().True(sign)
+		s.Require().Equal("5", data.(*binance.AmendOrderReq).NewQty)
+
+		return []byte(`{"transactTime":1741926410255,"executionId":75,"amendedOrder":{"symbol":"BTUCSDT","orderId":33,
+			"orderListId":-1,"origClientOrderId":"5xrgbMyg6z36NzBn2pbT8H","clientOrderId":"PFaq6hIHxqFENGfdtn4J6Q",
+			"price":"6.00000000","qty":"5.00000000","executedQty":"0.00000000","preventedQty":"0.00000000",
+			"quoteOrderQty":"0.00000000","cumulativeQuoteQty":"0.00000000","status":"NEW","timeInForce":"GTC",
+			"type":"LIMIT","side":"SELL","workingTime":1741926410242,"selfTradePreventionMode":"NONE"}}`), nil
+	}
+	resp, err := s.api.AmendOrderKeepPriority(&binance.AmendOrderReq{Symbol: "BTCUSDT", OrderID: 33, NewQty: "5"})
+	s.Require().NoError(err)
+	s.Require().EqualValues(75, resp.ExecutionID)
+	s.Require().Nil(resp.ListStatus)
+	s.Require().Equal("5.00000000", binance.FormatDecimal(resp.AmendedOrder.Qty))
+	s.Require().Equal("5xrgbMyg6z36NzBn2pbT8H", resp.AmendedOrder.OrigClientOrderID)
+
+	_, err = s.api.AmendOrderKeepPriority(&binance.AmendOrderReq{Symbol: "BTCUSDT", NewQty: "5"})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderID)
+	_, err = s.api.AmendOrderKeepPriority(&binance.AmendOrderReq{Symbol: "BTCUSDT", OrigClientOrderID: "x"})
+	s.Require().ErrorIs(err, binance.ErrEmptyQuantity)
+}
+
+func (s *mockedTestSuite) TestOrderAmendments() {
+	expected := []*binance.OrderAmendment{{
+		Symbol:            "BTCUSDT",
+		OrderID:           9,
+		ExecutionID:       22,
+		OrigClientOrderID: "W0fJ9fiLKHOJutovPK3oJp",
+		NewClientOrderID:  "UQ1Np3bmQ71jJzsSDW9Vpi",
+		OrigQty:           mustDecimal("5.00000000"),
+		NewQty:            mustDecimal("4.00000000"),
+		Time:              1741669661670,
+	}}
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointOrderAmendments, endpoint)
+		s.Require().Equal(binance.DefaultOrderLimit, data.(*binance.OrderAmendmentsReq).Limit)
+
+		return json.Marshal(expected)
+	}
+	actual, err := s.api.OrderAmendments(&binance.OrderAmendmentsReq{Symbol: "BTCUSDT", OrderID: 9})
+	s.Require().NoError(err)
+	s.requireEqualJSON(expected, actual)
+
+	_, err = s.api.OrderAmendments(&binance.OrderAmendmentsReq{Symbol: "BTCUSDT"})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderID)
+}
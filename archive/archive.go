@@ -0,0 +1,319 @@
+// Package archive reads historical market data files published at https://data.binance.vision.
+//
+// Klines, trades, aggTrades and bookTicker files are supported either as downloaded zip archives
+// or as extracted CSV files, with or without the header line. Timestamps in microseconds,
+// used by newer spot files, are converted to milliseconds like in REST responses.
+package archive
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+var (
+	ErrEmptyArchive     = errors.New("archive has no files")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrInvalidChecksum  = errors.New("invalid checksum file")
+	ErrInvalidRecord    = errors.New("invalid record")
+)
+
+// ChecksumSuffix is appended to the data file name to get its checksum file
+const ChecksumSuffix = ".CHECKSUM"
+
+// maxMsTimestamp separates millisecond timestamps from microsecond ones, it's year 5138 in ms
+const maxMsTimestamp = 1e14
+
+// BookTicker is a best bid and ask update from bookTicker files
+type BookTicker struct {
+	UpdateID        int64
+	BidPrice        decimal.Decimal
+	BidQty          decimal.Decimal
+	AskPrice        decimal.Decimal
+	AskQty          decimal.Decimal
+	TransactionTime uint64
+	EventTime       uint64
+}
+
+// Reader reads records of a single archive file, it must be closed after use
+type Reader struct {
+	csv     *csv.Reader
+	file    io.Closer
+	zip     io.Closer
+	started bool
+}
+
+// Open opens archive file, zip archives are read from the first CSV file inside
+func Open(path string) (*Reader, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".zip") {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return newReader(f, f, nil), nil
+	}
+
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(z.File) == 0 {
+		z.Close()
+
+		return nil, ErrEmptyArchive
+	}
+	entry := z.File[0]
+	for _, f := range z.File {
+		if strings.EqualFold(filepath.Ext(f.Name), ".csv") {
+			entry = f
+
+			break
+		}
+	}
+	f, err := entry.Open()
+	if err != nil {
+		z.Close()
+
+		return nil, err
+	}
+
+	return newReader(f, f, z), nil
+}
+
+// NewReader creates reader of CSV data
+func NewReader(r io.Reader) *Reader {
+	return newReader(r, nil, nil)
+}
+
+func newReader(r io.Reader, file, z io.Closer) *Reader {
+	c := csv.NewReader(bufio.NewReader(r))
+	c.FieldsPerRecord = -1
+	c.ReuseRecord = true
+
+	return &Reader{csv: c, file: file, zip: z}
+}
+
+// Close closes the underlying files
+func (r *Reader) Close() error {
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+	}
+	if r.zip != nil {
+		if zerr := r.zip.Close(); err == nil {
+			err = zerr
+		}
+	}
+
+	return err
+}
+
+// ReadKlines reads the next kline, it returns io.EOF at the end of file
+func (r *Reader) ReadKlines() (*binance.Klines, error) {
+	rec, err := r.read(11)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{rec: rec}
+	k := &binance.Klines{
+		OpenTime:                 p.time(0),
+		OpenPrice:                p.decimal(1),
+		High:                     p.decimal(2),
+		Low:                      p.decimal(3),
+		ClosePrice:               p.decimal(4),
+		Volume:                   p.decimal(5),
+		CloseTime:                p.time(6),
+		QuoteAssetVolume:         p.decimal(7),
+		Trades:                   int(p.int(8)),
+		TakerBuyBaseAssetVolume:  p.decimal(9),
+		TakerBuyQuoteAssetVolume: p.decimal(10),
+	}
+
+	return k, p.err
+}
+
+// ReadTrade reads the next trade, it returns io.EOF at the end of file
+func (r *Reader) ReadTrade() (*binance.Trade, error) {
+	rec, err := r.read(6)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{rec: rec}
+	t := &binance.Trade{
+		ID:           p.int(0),
+		Price:        p.decimal(1),
+		Qty:          p.decimal(2),
+		QuoteQty:     p.decimal(3),
+		Time:         int64(p.time(4)),
+		IsBuyerMaker: p.bool(5),
+	}
+	if len(rec) > 6 {
+		t.IsBestMatch = p.bool(6)
+	}
+
+	return t, p.err
+}
+
+// ReadAggregatedTrade reads the next aggregate trade, it returns io.EOF at the end of file
+func (r *Reader) ReadAggregatedTrade() (*binance.AggregatedTrade, error) {
+	rec, err := r.read(7)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{rec: rec}
+	t := &binance.AggregatedTrade{
+		TradeID:      p.int(0),
+		Price:        p.decimal(1),
+		Quantity:     p.decimal(2),
+		FirstTradeID: int(p.int(3)),
+		LastTradeID:  int(p.int(4)),
+		Time:         p.time(5),
+		Maker:        p.bool(6),
+	}
+	if len(rec) > 7 {
+		t.BestMatch = p.bool(7)
+	}
+
+	return t, p.err
+}
+
+// ReadBookTicker reads the next book ticker update, it returns io.EOF at the end of file
+func (r *Reader) ReadBookTicker() (*BookTicker, error) {
+	rec, err := r.read(7)
+	if err != nil {
+		return nil, err
+	}
+	p := parser{rec: rec}
+	t := &BookTicker{
+		UpdateID:        p.int(0),
+		BidPrice:        p.decimal(1),
+		BidQty:          p.decimal(2),
+		AskPrice:        p.decimal(3),
+		AskQty:          p.decimal(4),
+		TransactionTime: p.time(5),
+		EventTime:       p.time(6),
+	}
+
+	return t, p.err
+}
+
+// read returns the next record with at least n fields skipping the header line
+func (r *Reader) read(n int) ([]string, error) {
+	rec, err := r.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+	if !r.started {
+		r.started = true
+		if len(rec) > 0 && !isNumber(rec[0]) {
+			return r.read(n)
+		}
+	}
+	if len(rec) < n {
+		return nil, errors.Wrapf(ErrInvalidRecord, "expected %d fields, got %d", n, len(rec))
+	}
+
+	return rec, nil
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+
+	return err == nil
+}
+
+// parser keeps the first conversion error of a record
+type parser struct {
+	rec []string
+	err error
+}
+
+func (p *parser) fail(i int, err error) {
+	if p.err == nil {
+		p.err = errors.Wrapf(ErrInvalidRecord, "field %d %q: %v", i, p.rec[i], err)
+	}
+}
+
+func (p *parser) int(i int) int64 {
+	v, err := strconv.ParseInt(p.rec[i], 10, 64)
+	if err != nil {
+		p.fail(i, err)
+	}
+
+	return v
+}
+
+func (p *parser) time(i int) uint64 {
+	v, err := strconv.ParseUint(p.rec[i], 10, 64)
+	if err != nil {
+		p.fail(i, err)
+	}
+	if v >= maxMsTimestamp {
+		v /= 1000
+	}
+
+	return v
+}
+
+func (p *parser) decimal(i int) decimal.Decimal {
+	v, err := decimal.NewFromString(p.rec[i])
+	if err != nil {
+		p.fail(i, err)
+	}
+
+	return v
+}
+
+func (p *parser) bool(i int) bool {
+	v, err := strconv.ParseBool(p.rec[i])
+	if err != nil {
+		p.fail(i, err)
+	}
+
+	return v
+}
+
+// VerifyChecksum compares SHA256 of the file with the checksum from the file with ChecksumSuffix next to it
+func VerifyChecksum(path string) error {
+	raw, err := os.ReadFile(path + ChecksumSuffix)
+	if err != nil {
+		return err
+	}
+	fields := bytes.Fields(raw)
+	if len(fields) == 0 {
+		return ErrInvalidChecksum
+	}
+	expected, err := hex.DecodeString(string(fields[0]))
+	if err != nil || len(expected) != sha256.Size {
+		return ErrInvalidChecksum
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return err
+	}
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return errors.Wrapf(ErrChecksumMismatch, "%s", filepath.Base(path))
+	}
+
+	return nil
+}
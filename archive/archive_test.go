@@ -0,0 +1,118 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ugi1/binance-api/archive"
+)
+
+const klinesCSV = `open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore
+1704067200000000,42283.58000000,42298.62000000,42261.02000000,42298.61000000,35.92724000,1704067259999999,1519060.78591010,1327,23.17807000,979983.66373820,0
+1704067260000000,42298.62000000,42320.00000000,42298.61000000,42320.00000000,21.37550000,1704067319999999,904484.27041540,1106,14.20779000,601186.93131110,0
+`
+
+func writeZip(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name+".zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	entry, err := w.Create(name + ".csv")
+	require.NoError(t, err)
+	_, err = io.WriteString(entry, content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sum := sha256.Sum256(raw)
+	checksum := hex.EncodeToString(sum[:]) + "  " + name + ".zip\n"
+	require.NoError(t, os.WriteFile(path+archive.ChecksumSuffix, []byte(checksum), 0o600))
+
+	return path
+}
+
+func TestReadKlines(t *testing.T) {
+	path := writeZip(t, t.TempDir(), "BTCUSDT-1m-2024-01-01", klinesCSV)
+	require.NoError(t, archive.VerifyChecksum(path))
+
+	r, err := archive.Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	k, err := r.ReadKlines()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1704067200000), k.OpenTime)
+	require.Equal(t, uint64(1704067259999), k.CloseTime)
+	require.Equal(t, "42283.58", k.OpenPrice.String())
+	require.Equal(t, 1327, k.Trades)
+	require.Equal(t, "979983.6637382", k.TakerBuyQuoteAssetVolume.String())
+
+	k, err = r.ReadKlines()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1704067260000), k.OpenTime)
+	_, err = r.ReadKlines()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadTrades(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "BTCUSDT-trades-2024-01-01.csv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"3350000000,42283.58000000,0.00100000,42.28358000,1704067200012,True,True\n"+
+			"3350000001,42283.59000000,0.00200000,84.56718000,1704067200012,false,true\n"), 0o600))
+
+	r, err := archive.Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+	tr, err := r.ReadTrade()
+	require.NoError(t, err)
+	require.Equal(t, int64(3350000000), tr.ID)
+	require.Equal(t, int64(1704067200012), tr.Time)
+	require.True(t, tr.IsBuyerMaker)
+	tr, err = r.ReadTrade()
+	require.NoError(t, err)
+	require.False(t, tr.IsBuyerMaker)
+	require.Equal(t, "0.002", tr.Qty.String())
+
+	agg := archive.NewReader(strings.NewReader(
+		"agg_trade_id,price,quantity,first_trade_id,last_trade_id,transact_time,is_buyer_maker,is_best_match\n" +
+			"2980000000,42283.58000000,0.00300000,3350000000,3350000001,1704067200012345,true,true\n"))
+	at, err := agg.ReadAggregatedTrade()
+	require.NoError(t, err)
+	require.Equal(t, int64(2980000000), at.TradeID)
+	require.Equal(t, 3350000001, at.LastTradeID)
+	require.Equal(t, uint64(1704067200012), at.Time)
+	require.True(t, at.Maker)
+
+	book := archive.NewReader(strings.NewReader("1,42283.58,1.5,42283.59,2.5,1704067200012,1704067200013\n"))
+	bt, err := book.ReadBookTicker()
+	require.NoError(t, err)
+	require.Equal(t, "42283.59", bt.AskPrice.String())
+	require.Equal(t, uint64(1704067200013), bt.EventTime)
+
+	bad := archive.NewReader(strings.NewReader("1,abc,1,1,1,1,1\n"))
+	_, err = bad.ReadTrade()
+	require.ErrorIs(t, err, archive.ErrInvalidRecord)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := writeZip(t, dir, "BTCUSDT-1m-2024-01-01", klinesCSV)
+	require.NoError(t, os.WriteFile(path+archive.ChecksumSuffix, []byte(strings.Repeat("0", 64)+"  x.zip\n"), 0o600))
+	require.ErrorIs(t, archive.VerifyChecksum(path), archive.ErrChecksumMismatch)
+
+	require.NoError(t, os.WriteFile(path+archive.ChecksumSuffix, []byte("zz"), 0o600))
+	require.ErrorIs(t, archive.VerifyChecksum(path), archive.ErrInvalidChecksum)
+}
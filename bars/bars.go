@@ -0,0 +1,328 @@
+// Package bars builds time, tick, volume and quote volume bars from Binance trades.
+//
+// Builder consumes live ws.TradeUpdate and ws.AggTradeUpdate as well as REST binance.Trade and
+// binance.AggregatedTrade, so history can be backfilled before switching to the stream.
+// A builder is fed either by trades or by aggregate trades, adding the other kind returns ErrMixedTrades:
+//
+//	b, _ := bars.NewTimeBuilder(250 * time.Millisecond)
+//	for {
+//		u, err := stream.Read()
+//		if err != nil {
+//			return err
+//		}
+//		bar, err := b.AddTradeUpdate(u)
+//		if err != nil {
+//			return err
+//		}
+//		if bar != nil {
+//			// bar is closed
+//		}
+//	}
+package bars
+
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/ws"
+)
+
+var (
+	ErrInvalidInterval  = errors.New("bar interval must be at least 1ms")
+	ErrInvalidThreshold = errors.New("bar threshold must be positive")
+	ErrMixedTrades      = errors.New("trades and aggregate trades can't be added to one builder")
+)
+
+// Type represents the rule used to close bars
+type Type string
+
+const (
+	TypeTime        Type = "TIME"         // TypeTime bars are closed on interval boundaries
+	TypeTick        Type = "TICK"         // TypeTick bars are closed after the given number of trades
+	TypeVolume      Type = "VOLUME"       // TypeVolume bars are closed when base asset volume reaches the threshold
+	TypeQuoteVolume Type = "QUOTE_VOLUME" // TypeQuoteVolume bars are closed when quote asset volume reaches the threshold
+)
+
+// Trade is a single trade print or an aggregate trade regardless of its source
+type Trade struct {
+	ID         int64  // ID is the trade ID, it's the first trade ID of an aggregate trade
+	LastID     int64  // LastID is the last trade ID of an aggregate trade, it's ignored for single trades
+	Aggregate  bool   // Aggregate marks aggregate trades, a builder accepts only one kind of trades
+	Time       uint64 // Time is the trade time in ms
+	Price      decimal.Decimal
+	Qty        decimal.Decimal
+	BuyerMaker bool // BuyerMaker means the taker is the seller
+}
+
+// Bar is an aggregate of consecutive trades
+type Bar struct {
+	OpenTime     uint64 // OpenTime is the interval start for time bars and the first trade time for others
+	CloseTime    uint64 // CloseTime is the interval end - 1ms for time bars and the last trade time for others
+	FirstTradeID int64
+	LastTradeID  int64
+	Trades       int
+
+	Open  decimal.Decimal
+	High  decimal.Decimal
+	Low   decimal.Decimal
+	Close decimal.Decimal
+
+	Volume               decimal.Decimal // Volume is the base asset volume
+	QuoteVolume          decimal.Decimal // QuoteVolume is the quote asset volume
+	TakerBuyVolume       decimal.Decimal
+	TakerBuyQuoteVolume  decimal.Decimal
+	TakerSellVolume      decimal.Decimal
+	TakerSellQuoteVolume decimal.Decimal
+}
+
+// Builder aggregates trades into bars, it isn't safe for concurrent use.
+// Trades with IDs not greater than the last added one are skipped, so overlapping backfill and stream are merged.
+// A trade is never split between bars, volume bars are closed by the trade which reaches the threshold.
+// Aggregate trades are counted by their trade ID range, so a tick bar may get more trades than requested.
+//
+// A builder is fed by one kind of trades: either AddTrade and AddTradeUpdate, or AddAggregatedTrade
+// and AddAggTradeUpdate. The kind is fixed by the first added trade and the other kind returns ErrMixedTrades
+type Builder struct {
+	typ       Type
+	interval  uint64
+	ticks     int
+	threshold decimal.Decimal
+
+	cur    *Bar
+	end    uint64
+	lastID int64
+	kind   tradeKind
+}
+
+// tradeKind is the kind of trades fed to a builder
+type tradeKind uint8
+
+const (
+	kindUnknown tradeKind = iota
+	kindTrades
+	kindAggTrades
+)
+
+// NewTimeBuilder creates builder of time bars aligned to multiples of interval since Unix epoch.
+// Intervals from 1ms are supported, intervals without trades don't produce bars
+func NewTimeBuilder(interval time.Duration) (*Builder, error) {
+	if interval < time.Millisecond {
+		return nil, ErrInvalidInterval
+	}
+
+	return &Builder{typ: TypeTime, interval: uint64(interval.Milliseconds()), lastID: -1}, nil
+}
+
+// NewTickBuilder creates builder of bars with the given number of trades
+func NewTickBuilder(trades int) (*Builder, error) {
+	if trades <= 0 {
+		return nil, ErrInvalidThreshold
+	}
+
+	return &Builder{typ: TypeTick, ticks: trades, lastID: -1}, nil
+}
+
+// NewVolumeBuilder creates builder of bars with the given base asset volume
+func NewVolumeBuilder(volume decimal.Decimal) (*Builder, error) {
+	if !volume.IsPositive() {
+		return nil, ErrInvalidThreshold
+	}
+
+	return &Builder{typ: TypeVolume, threshold: volume, lastID: -1}, nil
+}
+
+// NewQuoteVolumeBuilder creates builder of bars with the given quote asset volume
+func NewQuoteVolumeBuilder(volume decimal.Decimal) (*Builder, error) {
+	if !volume.IsPositive() {
+		return nil, ErrInvalidThreshold
+	}
+
+	return &Builder{typ: TypeQuoteVolume, threshold: volume, lastID: -1}, nil
+}
+
+// Type returns the bar type
+func (b *Builder) Type() Type {
+	return b.typ
+}
+
+// Add adds trade and returns the bar closed by it or nil.
+// Time bar is closed by the first trade of a later interval, which then opens the next bar
+func (b *Builder) Add(t Trade) (*Bar, error) {
+	kind := kindTrades
+	if t.Aggregate {
+		kind = kindAggTrades
+	}
+	if b.kind != kindUnknown && b.kind != kind {
+		return nil, ErrMixedTrades
+	}
+	b.kind = kind
+	if t.ID <= b.lastID {
+		return nil, nil
+	}
+	b.lastID = t.lastID()
+
+	var closed *Bar
+	if b.typ == TypeTime && b.cur != nil && t.Time >= b.end {
+		closed = b.cur
+		b.cur = nil
+	}
+	if b.cur == nil {
+		b.open(t)
+	} else {
+		b.update(t)
+	}
+
+	switch b.typ { //nolint:exhaustive
+	case TypeTick:
+		if b.cur.Trades >= b.ticks {
+			closed = b.Flush()
+		}
+	case TypeVolume:
+		if b.cur.Volume.GreaterThanOrEqual(b.threshold) {
+			closed = b.Flush()
+		}
+	case TypeQuoteVolume:
+		if b.cur.QuoteVolume.GreaterThanOrEqual(b.threshold) {
+			closed = b.Flush()
+		}
+	}
+
+	return closed, nil
+}
+
+// AddTradeUpdate adds trade from the trades stream
+func (b *Builder) AddTradeUpdate(u *ws.TradeUpdate) (*Bar, error) {
+	return b.Add(Trade{ID: u.TradeID, Time: u.TradeTime, Price: u.Price, Qty: u.Quantity, BuyerMaker: u.Maker})
+}
+
+// AddAggTradeUpdate adds aggregate trade from the aggregated trades stream
+func (b *Builder) AddAggTradeUpdate(u *ws.AggTradeUpdate) (*Bar, error) {
+	return b.Add(Trade{
+		ID:         u.FirstBreakDownTradeID,
+		LastID:     u.LastBreakDownTradeID,
+		Aggregate:  true,
+		Time:       u.TradeTime,
+		Price:      u.Price,
+		Qty:        u.Quantity,
+		BuyerMaker: u.Maker,
+	})
+}
+
+// AddTrade adds trade returned by Trades or HistoricalTrades
+func (b *Builder) AddTrade(t *binance.Trade) (*Bar, error) {
+	return b.Add(Trade{ID: t.ID, Time: uint64(t.Time), Price: t.Price, Qty: t.Qty, BuyerMaker: t.IsBuyerMaker})
+}
+
+// AddAggregatedTrade adds aggregate trade returned by AggregatedTrades
+func (b *Builder) AddAggregatedTrade(t *binance.AggregatedTrade) (*Bar, error) {
+	return b.Add(Trade{
+		ID:         int64(t.FirstTradeID),
+		LastID:     int64(t.LastTradeID),
+		Aggregate:  true,
+		Time:       t.Time,
+		Price:      t.Price,
+		Qty:        t.Quantity,
+		BuyerMaker: t.Maker,
+	})
+}
+
+// Advance closes the current time bar if now is past its interval, it's used to emit bars when trades stop.
+// It returns nil for other bar types
+func (b *Builder) Advance(now time.Time) *Bar {
+	if b.typ != TypeTime || b.cur == nil || binance.TimeToMs(now) < b.end {
+		return nil
+	}
+
+	return b.Flush()
+}
+
+// Current returns copy of the bar being built or nil
+func (b *Builder) Current() *Bar {
+	if b.cur == nil {
+		return nil
+	}
+	bar := *b.cur
+
+	return &bar
+}
+
+// Flush closes and returns the bar being built, it returns nil if there are no trades since the last bar
+func (b *Builder) Flush() *Bar {
+	bar := b.cur
+	b.cur = nil
+
+	return bar
+}
+
+func (b *Builder) open(t Trade) {
+	quote := t.Price.Mul(t.Qty)
+	bar := &Bar{
+		OpenTime:     t.Time,
+		CloseTime:    t.Time,
+		FirstTradeID: t.ID,
+		LastTradeID:  t.lastID(),
+		Trades:       t.count(),
+		Open:         t.Price,
+		High:         t.Price,
+		Low:          t.Price,
+		Close:        t.Price,
+		Volume:       t.Qty,
+		QuoteVolume:  quote,
+	}
+	if t.BuyerMaker {
+		bar.TakerSellVolume, bar.TakerSellQuoteVolume = t.Qty, quote
+		bar.TakerBuyVolume, bar.TakerBuyQuoteVolume = decimal.Zero, decimal.Zero
+	} else {
+		bar.TakerBuyVolume, bar.TakerBuyQuoteVolume = t.Qty, quote
+		bar.TakerSellVolume, bar.TakerSellQuoteVolume = decimal.Zero, decimal.Zero
+	}
+	if b.typ == TypeTime {
+		bar.OpenTime = t.Time - t.Time%b.interval
+		b.end = bar.OpenTime + b.interval
+		bar.CloseTime = b.end - 1
+	}
+	b.cur = bar
+}
+
+func (b *Builder) update(t Trade) {
+	bar := b.cur
+	quote := t.Price.Mul(t.Qty)
+	if t.Price.GreaterThan(bar.High) {
+		bar.High = t.Price
+	}
+	if t.Price.LessThan(bar.Low) {
+		bar.Low = t.Price
+	}
+	bar.Close = t.Price
+	bar.Volume = bar.Volume.Add(t.Qty)
+	bar.QuoteVolume = bar.QuoteVolume.Add(quote)
+	if t.BuyerMaker {
+		bar.TakerSellVolume = bar.TakerSellVolume.Add(t.Qty)
+		bar.TakerSellQuoteVolume = bar.TakerSellQuoteVolume.Add(quote)
+	} else {
+		bar.TakerBuyVolume = bar.TakerBuyVolume.Add(t.Qty)
+		bar.TakerBuyQuoteVolume = bar.TakerBuyQuoteVolume.Add(quote)
+	}
+	bar.Trades += t.count()
+	bar.LastTradeID = t.lastID()
+	if b.typ != TypeTime {
+		bar.CloseTime = t.Time
+	}
+}
+
+// lastID returns the last trade ID of the trade
+func (t *Trade) lastID() int64 {
+	if t.Aggregate && t.LastID > t.ID {
+		return t.LastID
+	}
+
+	return t.ID
+}
+
+// count returns the number of trades in the trade
+func (t *Trade) count() int {
+	return int(t.lastID()-t.ID) + 1
+}
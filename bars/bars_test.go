@@ -0,0 +1,140 @@
+package bars_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/bars"
+	"github.com/ugi1/binance-api/ws"
+)
+
+func trade(id int64, ms uint64, price, qty string, buyerMaker bool) bars.Trade {
+	return bars.Trade{
+		ID:         id,
+		Time:       ms,
+		Price:      decimal.RequireFromString(price),
+		Qty:        decimal.RequireFromString(qty),
+		BuyerMaker: buyerMaker,
+	}
+}
+
+// noBar returns a check of Add results not closing a bar
+func noBar(t *testing.T) func(*bars.Bar, error) {
+	return func(bar *bars.Bar, err error) {
+		t.Helper()
+		require.NoError(t, err)
+		require.Nil(t, bar)
+	}
+}
+
+// closedBar returns a check of Add results closing a bar
+func closedBar(t *testing.T) func(*bars.Bar, error) *bars.Bar {
+	return func(bar *bars.Bar, err error) *bars.Bar {
+		t.Helper()
+		require.NoError(t, err)
+		require.NotNil(t, bar)
+
+		return bar
+	}
+}
+
+func TestTimeBars(t *testing.T) {
+	b, err := bars.NewTimeBuilder(250 * time.Millisecond)
+	require.NoError(t, err)
+
+	noBar(t)(b.Add(trade(1, 1000, "10", "1", false)))
+	noBar(t)(b.Add(trade(2, 1100, "12", "2", true)))
+	noBar(t)(b.Add(trade(2, 1100, "12", "2", true)))
+	noBar(t)(b.Add(trade(3, 1249, "9", "1", false)))
+
+	bar := closedBar(t)(b.Add(trade(4, 1700, "11", "1", false)))
+	require.Equal(t, uint64(1000), bar.OpenTime)
+	require.Equal(t, uint64(1249), bar.CloseTime)
+	require.Equal(t, 3, bar.Trades)
+	require.Equal(t, int64(1), bar.FirstTradeID)
+	require.Equal(t, int64(3), bar.LastTradeID)
+	require.Equal(t, "10", bar.Open.String())
+	require.Equal(t, "12", bar.High.String())
+	require.Equal(t, "9", bar.Low.String())
+	require.Equal(t, "9", bar.Close.String())
+	require.Equal(t, "4", bar.Volume.String())
+	require.Equal(t, "43", bar.QuoteVolume.String())
+	require.Equal(t, "2", bar.TakerBuyVolume.String())
+	require.Equal(t, "19", bar.TakerBuyQuoteVolume.String())
+	require.Equal(t, "2", bar.TakerSellVolume.String())
+	require.Equal(t, "24", bar.TakerSellQuoteVolume.String())
+
+	require.Equal(t, uint64(1500), b.Current().OpenTime)
+	require.Nil(t, b.Advance(binance.MsToTime(1749)))
+	bar = b.Advance(binance.MsToTime(1750))
+	require.NotNil(t, bar)
+	require.Equal(t, int64(4), bar.FirstTradeID)
+	require.Nil(t, b.Current())
+
+	_, err = bars.NewTimeBuilder(time.Microsecond)
+	require.ErrorIs(t, err, bars.ErrInvalidInterval)
+}
+
+func TestThresholdBars(t *testing.T) {
+	b, err := bars.NewTickBuilder(2)
+	require.NoError(t, err)
+	noBar(t)(b.Add(trade(1, 1000, "10", "1", false)))
+	bar := closedBar(t)(b.Add(trade(2, 1005, "11", "1", false)))
+	require.Equal(t, uint64(1000), bar.OpenTime)
+	require.Equal(t, uint64(1005), bar.CloseTime)
+
+	b, err = bars.NewVolumeBuilder(decimal.RequireFromString("1.5"))
+	require.NoError(t, err)
+	noBar(t)(b.Add(trade(1, 1000, "10", "1", false)))
+	bar = closedBar(t)(b.Add(trade(2, 1001, "10", "1", true)))
+	require.Equal(t, "2", bar.Volume.String())
+
+	// REST backfill of trades followed by the overlapping trades stream
+	b, err = bars.NewQuoteVolumeBuilder(decimal.NewFromInt(100))
+	require.NoError(t, err)
+	noBar(t)(b.AddTrade(&binance.Trade{ID: 1, Time: 1000, Price: decimal.NewFromInt(50), Qty: decimal.NewFromInt(1)}))
+	noBar(t)(b.AddTrade(&binance.Trade{ID: 2, Time: 1001, Price: decimal.NewFromInt(40), Qty: decimal.NewFromInt(1)}))
+	noBar(t)(b.AddTradeUpdate(&ws.TradeUpdate{TradeID: 2, TradeTime: 1001, Price: decimal.NewFromInt(40), Quantity: decimal.NewFromInt(1)}))
+	bar = closedBar(t)(b.AddTradeUpdate(&ws.TradeUpdate{TradeID: 3, TradeTime: 1002, Price: decimal.NewFromInt(10), Quantity: decimal.NewFromInt(1), Maker: true}))
+	require.Equal(t, 3, bar.Trades)
+	require.Equal(t, "100", bar.QuoteVolume.String())
+	require.Equal(t, "10", bar.TakerSellQuoteVolume.String())
+
+	// REST backfill of aggregate trades followed by the overlapping aggregate trades stream,
+	// aggregate trades are counted by their trade ID ranges
+	b, err = bars.NewQuoteVolumeBuilder(decimal.NewFromInt(100))
+	require.NoError(t, err)
+	noBar(t)(b.AddAggregatedTrade(&binance.AggregatedTrade{TradeID: 7, FirstTradeID: 20, LastTradeID: 22,
+		Time: 1000, Price: decimal.NewFromInt(50), Quantity: decimal.NewFromInt(1)}))
+	noBar(t)(b.AddAggTradeUpdate(&ws.AggTradeUpdate{TradeID: 7, FirstBreakDownTradeID: 20, LastBreakDownTradeID: 22,
+		TradeTime: 1000, Price: decimal.NewFromInt(50), Quantity: decimal.NewFromInt(1)}))
+	noBar(t)(b.AddAggTradeUpdate(&ws.AggTradeUpdate{TradeID: 8, FirstBreakDownTradeID: 23, LastBreakDownTradeID: 23,
+		TradeTime: 1001, Price: decimal.NewFromInt(10), Quantity: decimal.NewFromInt(1)}))
+	bar = b.Flush()
+	require.Equal(t, 4, bar.Trades)
+	require.Equal(t, int64(20), bar.FirstTradeID)
+	require.Equal(t, int64(23), bar.LastTradeID)
+	require.Equal(t, "60", bar.QuoteVolume.String())
+
+	// the builder is fed by aggregate trades, single trades are rejected
+	_, err = b.AddTrade(&binance.Trade{ID: 24, Time: 1002, Price: decimal.NewFromInt(10), Qty: decimal.NewFromInt(1)})
+	require.ErrorIs(t, err, bars.ErrMixedTrades)
+	require.Nil(t, b.Current())
+
+	// an aggregate trade isn't split between tick bars
+	b, err = bars.NewTickBuilder(3)
+	require.NoError(t, err)
+	noBar(t)(b.Add(bars.Trade{ID: 1, LastID: 2, Aggregate: true, Time: 1000, Price: decimal.NewFromInt(1), Qty: decimal.NewFromInt(2)}))
+	bar = closedBar(t)(b.Add(bars.Trade{ID: 3, LastID: 4, Aggregate: true, Time: 1001, Price: decimal.NewFromInt(1), Qty: decimal.NewFromInt(2)}))
+	require.Equal(t, 4, bar.Trades)
+	require.Equal(t, int64(4), bar.LastTradeID)
+	_, err = b.Add(trade(5, 1002, "1", "1", false))
+	require.ErrorIs(t, err, bars.ErrMixedTrades)
+
+	_, err = bars.NewVolumeBuilder(decimal.Zero)
+	require.ErrorIs(t, err, bars.ErrInvalidThreshold)
+}
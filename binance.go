@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"github.com/go-faster/errors"
 	"github.com/segmentio/encoding/json"
 	"github.com/valyala/fasthttp"
 )
@@ -278,21 +279,8 @@ func (c *Client) NewOrder(req *OrderReq) (*OrderRespAck, error) {
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	switch req.Type { //nolint:exhaustive
-	case OrderTypeLimit:
-		if req.Price == "" || req.Quantity == "" {
-			return nil, ErrEmptyLimit
-		}
-		if req.TimeInForce == "" {
-			req.TimeInForce = TimeInForceGTC
-		}
-	case OrderTypeMarket:
-		if req.Quantity == "" && req.QuoteQuantity == "" {
-			return nil, ErrEmptyMarket
-		}
-	}
-	if req.StrategyType > 0 && req.StrategyType < MinStrategyType {
-		return nil, ErrMinStrategyType
+	if err := req.validate(); err != nil {
+		return nil, err
 	}
 	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
 	if err != nil {
@@ -310,18 +298,8 @@ func (c *Client) NewOrderResult(req *OrderReq) (*OrderRespResult, error) {
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	switch req.Type { //nolint:exhaustive
-	case OrderTypeLimit:
-		if req.Price == "" || req.Quantity == "" {
-			return nil, ErrEmptyLimit
-		}
-		if req.TimeInForce == "" {
-			req.TimeInForce = TimeInForceGTC
-		}
-	case OrderTypeMarket:
-		if req.Quantity == "" && req.QuoteQuantity == "" {
-			return nil, ErrEmptyMarket
-		}
+	if err := req.validate(); err != nil {
+		return nil, err
 	}
 	req.OrderRespType = OrderRespTypeResult
 	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
@@ -340,18 +318,8 @@ func (c *Client) NewOrderFull(req *OrderReq) (*OrderRespFull, error) {
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	switch req.Type { //nolint:exhaustive
-	case OrderTypeLimit:
-		if req.Price == "" || req.Quantity == "" {
-			return nil, ErrEmptyLimit
-		}
-		if req.TimeInForce == "" {
-			req.TimeInForce = TimeInForceGTC
-		}
-	case OrderTypeMarket:
-		if req.Quantity == "" && req.QuoteQuantity == "" {
-			return nil, ErrEmptyMarket
-		}
+	if err := req.validate(); err != nil {
+		return nil, err
 	}
 	req.OrderRespType = OrderRespTypeFull
 	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
@@ -370,6 +338,9 @@ func (c *Client) NewOrderTest(req *OrderReq) error {
 	if req == nil {
 		return ErrNilRequest
 	}
+	if err := req.validate(); err != nil {
+		return err
+	}
 	_, err := c.Do(fasthttp.MethodPost, EndpointOrderTest, req, true, false)
 
 	return err
@@ -411,7 +382,8 @@ func (c *Client) CancelOrder(req *CancelOrderReq) (*CancelOrder, error) {
 	return resp, err
 }
 
-// CancelReplaceOrder cancels an existing order and places a new order on the same symbol
+// CancelReplaceOrder cancels an existing order and places a new order on the same symbol.
+// When any of the operations fails the result is returned together with *CancelReplaceError
 func (c *Client) CancelReplaceOrder(req *CancelReplaceOrderReq) (*CancelReplaceOrder, error) {
 	if req == nil {
 		return nil, ErrNilRequest
@@ -422,22 +394,12 @@ func (c *Client) CancelReplaceOrder(req *CancelReplaceOrderReq) (*CancelReplaceO
 	if req.CancelReplaceMode == "" {
 		req.CancelReplaceMode = CancelReplaceModeStopOnFailure
 	}
-	switch req.Type { //nolint:exhaustive
-	case OrderTypeLimit:
-		if req.Price == "" || req.Quantity == "" {
-			return nil, ErrEmptyLimit
-		}
-		if req.TimeInForce == "" {
-			req.TimeInForce = TimeInForceGTC
-		}
-	case OrderTypeMarket:
-		if req.Quantity == "" && req.QuoteQuantity == "" {
-			return nil, ErrEmptyMarket
-		}
+	if err := req.OrderReq.validate(); err != nil {
+		return nil, err
 	}
 	res, err := c.Do(fasthttp.MethodPost, EndpointCancelReplaceOrder, req, true, false)
 	if err != nil {
-		return nil, err
+		return cancelReplaceFailure(err)
 	}
 	resp := &CancelReplaceOrder{}
 	err = json.Unmarshal(res, resp)
@@ -445,6 +407,21 @@ func (c *Client) CancelReplaceOrder(req *CancelReplaceOrderReq) (*CancelReplaceO
 	return resp, err
 }
 
+// cancelReplaceFailure decodes the cancel-replace result of the failed request.
+// The result is returned together with *CancelReplaceError, so the caller can check which operation succeeded
+func cancelReplaceFailure(err error) (*CancelReplaceOrder, error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Data) == 0 {
+		return nil, err
+	}
+	resp := &CancelReplaceOrder{}
+	if json.Unmarshal(apiErr.Data, resp) != nil {
+		return nil, err
+	}
+
+	return resp, &CancelReplaceError{APIError: apiErr, Result: resp}
+}
+
 // OpenOrders get all open orders on a symbol
 func (c *Client) OpenOrders(req *OpenOrdersReq) ([]*QueryOrder, error) {
 	if req == nil {
@@ -506,7 +483,7 @@ func (c *Client) Account() (*AccountInfo, error) {
 }
 
 // AccountTrades get trades for a specific account and symbol
-func (c *Client) AccountTrades(req *AccountTradesReq) (*AccountTrades, error) {
+func (c *Client) AccountTrades(req *AccountTradesReq) ([]*AccountTrades, error) {
 	if req == nil {
 		return nil, ErrNilRequest
 	}
@@ -517,7 +494,7 @@ func (c *Client) AccountTrades(req *AccountTradesReq) (*AccountTrades, error) {
 	if err != nil {
 		return nil, err
 	}
-	resp := &AccountTrades{}
+	var resp []*AccountTrades
 	err = json.Unmarshal(res, &resp)
 
 	return resp, err
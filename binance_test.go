@@ -1,12 +1,15 @@
 package binance_test
 
 import (
-	"github.com/xenking/binance-api"
 	"math/rand"
 	"testing"
 
+	"github.com/google/go-querystring/query"
 	"github.com/segmentio/encoding/json"
 	"github.com/stretchr/testify/suite"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
 )
 
 func TestClient(t *testing.T) {
@@ -123,12 +126,13 @@ func (s *clientHTTP2TestSuite) SetupSuite() {
 }
 
 type mockedClient struct {
-	Response func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error)
-	window   int
+	Response   func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error)
+	window     int
+	usedWeight map[string]int64
 }
 
 func (m *mockedClient) UsedWeight() map[string]int64 {
-	panic("not used")
+	return m.usedWeight
 }
 
 func (m *mockedClient) OrderCount() map[string]int64 {
@@ -136,7 +140,7 @@ func (m *mockedClient) OrderCount() map[string]int64 {
 }
 
 func (m *mockedClient) RetryAfter() int64 {
-	panic("not used")
+	return 0
 }
 
 func (m *mockedClient) SetWindow(w int) {
@@ -159,6 +163,25 @@ func (s *mockedTestSuite) SetupSuite() {
 
 func (s *mockedTestSuite) SetupTest() {
 	s.mock.Response = nil
+	s.mock.usedWeight = nil
+}
+
+// requireEqualJSON compares values by their json encoding,
+// since equal decimals may differ in the internal representation
+func (s *mockedTestSuite) requireEqualJSON(expected, actual interface{}) {
+	expectedJSON, err := json.Marshal(expected)
+	s.Require().NoError(err)
+	actualJSON, err := json.Marshal(actual)
+	s.Require().NoError(err)
+	s.Require().JSONEq(string(expectedJSON), string(actualJSON))
+}
+
+func mustDecimal(s string) decimal.Decimal {
+	if s == "" {
+		return decimal.Decimal{}
+	}
+
+	return decimal.RequireFromString(s)
 }
 
 func (s *mockedTestSuite) TestHistoricalTrades() {
@@ -169,9 +192,9 @@ func (s *mockedTestSuite) TestHistoricalTrades() {
 		expected = []*binance.Trade{
 			{
 				ID:       rand.Int63(),
-				Price:    "0.1",
-				Qty:      "1",
-				QuoteQty: "1",
+				Price:    mustDecimal("0.1"),
+				Qty:      mustDecimal("1"),
+				QuoteQty: mustDecimal("1"),
 				Time:     rand.Int63(),
 			},
 		}
@@ -223,7 +246,6 @@ func (s *mockedTestSuite) TestNewMarketOrder() {
 		Side:     binance.OrderSideSell,
 		Type:     binance.OrderTypeMarket,
 		Quantity: "1",
-		Price:    "0.1",
 	})
 	s.Require().NoError(e)
 	s.Require().EqualValues(expected, actual)
@@ -261,10 +283,10 @@ func (s *mockedTestSuite) TestNewOrderResult() {
 			Symbol:              req.Symbol,
 			OrderID:             rand.Uint64(),
 			TransactTime:        rand.Uint64(),
-			Price:               req.Price,
-			OrigQty:             req.Quantity,
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: req.QuoteQuantity,
+			Price:               mustDecimal(req.Price),
+			OrigQty:             mustDecimal(req.Quantity),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal(req.QuoteQuantity),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         string(req.TimeInForce),
 			Type:                req.Type,
@@ -282,7 +304,7 @@ func (s *mockedTestSuite) TestNewOrderResult() {
 		Price:       "0.1",
 	})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expected, actual)
+	s.requireEqualJSON(expected, actual)
 }
 
 func (s *mockedTestSuite) TestNewOrderFull() {
@@ -294,10 +316,10 @@ func (s *mockedTestSuite) TestNewOrderFull() {
 			Symbol:              req.Symbol,
 			OrderID:             rand.Uint64(),
 			TransactTime:        rand.Uint64(),
-			Price:               req.Price,
-			OrigQty:             req.Quantity,
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: req.QuoteQuantity,
+			Price:               mustDecimal(req.Price),
+			OrigQty:             mustDecimal(req.Quantity),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal(req.QuoteQuantity),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         string(req.TimeInForce),
 			Type:                req.Type,
@@ -345,17 +367,17 @@ func (s *mockedTestSuite) TestQueryCancelOrder() {
 		expectedQuery = &binance.QueryOrder{
 			Symbol:              req.Symbol,
 			OrderID:             req.OrderID,
-			Price:               createReq.Price,
-			OrigQty:             createReq.Quantity,
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: createReq.Quantity,
+			Price:               mustDecimal(createReq.Price),
+			OrigQty:             mustDecimal(createReq.Quantity),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal(createReq.Quantity),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         createReq.TimeInForce,
 			Type:                createReq.Type,
 			Side:                createReq.Side,
 			Time:                rand.Uint64(),
 			UpdateTime:          rand.Uint64(),
-			OrigQuoteOrderQty:   createReq.QuoteQuantity,
+			OrigQuoteOrderQty:   mustDecimal(createReq.QuoteQuantity),
 		}
 		return json.Marshal(expectedQuery)
 	}
@@ -364,7 +386,7 @@ func (s *mockedTestSuite) TestQueryCancelOrder() {
 		OrderID: resp.OrderID,
 	})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expectedQuery, actualQuery)
+	s.requireEqualJSON(expectedQuery, actualQuery)
 
 	var expectedCancel *binance.CancelOrder
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
@@ -388,7 +410,7 @@ func (s *mockedTestSuite) TestQueryCancelOrder() {
 		OrderID: resp.OrderID,
 	})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expectedCancel, actualCancel)
+	s.requireEqualJSON(expectedCancel, actualCancel)
 }
 
 func (s *mockedTestSuite) TestCancelReplaceOrder() {
@@ -419,17 +441,17 @@ func (s *mockedTestSuite) TestCancelReplaceOrder() {
 		expectedQuery = &binance.QueryOrder{
 			Symbol:              req.Symbol,
 			OrderID:             req.OrderID,
-			Price:               createReq.Price,
-			OrigQty:             createReq.Quantity,
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: createReq.Quantity,
+			Price:               mustDecimal(createReq.Price),
+			OrigQty:             mustDecimal(createReq.Quantity),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal(createReq.Quantity),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         createReq.TimeInForce,
 			Type:                createReq.Type,
 			Side:                createReq.Side,
 			Time:                rand.Uint64(),
 			UpdateTime:          rand.Uint64(),
-			OrigQuoteOrderQty:   createReq.QuoteQuantity,
+			OrigQuoteOrderQty:   mustDecimal(createReq.QuoteQuantity),
 		}
 		return json.Marshal(expectedQuery)
 	}
@@ -438,7 +460,7 @@ func (s *mockedTestSuite) TestCancelReplaceOrder() {
 		OrderID: resp.OrderID,
 	})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expectedQuery, actualQuery)
+	s.requireEqualJSON(expectedQuery, actualQuery)
 
 	req := &binance.CancelReplaceOrderReq{
 		OrderReq:      *createReq,
@@ -467,10 +489,10 @@ func (s *mockedTestSuite) TestCancelReplaceOrder() {
 				Symbol:              req.Symbol,
 				OrderID:             rand.Uint64(),
 				TransactTime:        rand.Uint64(),
-				Price:               req.Price,
-				OrigQty:             req.Quantity,
-				ExecutedQty:         "0",
-				CummulativeQuoteQty: req.QuoteQuantity,
+				Price:               mustDecimal(req.Price),
+				OrigQty:             mustDecimal(req.Quantity),
+				ExecutedQty:         mustDecimal("0"),
+				CummulativeQuoteQty: mustDecimal(req.QuoteQuantity),
 				Status:              binance.OrderStatusNew,
 				TimeInForce:         string(req.TimeInForce),
 				Type:                req.Type,
@@ -481,7 +503,69 @@ func (s *mockedTestSuite) TestCancelReplaceOrder() {
 	}
 	actualCancel, e := s.api.CancelReplaceOrder(req)
 	s.Require().NoError(e)
-	s.Require().EqualValues(expectedCancel, actualCancel)
+	s.requireEqualJSON(expectedCancel, actualCancel)
+}
+
+func (s *mockedTestSuite) TestCancelReplaceOrderFailure() {
+	req := &binance.CancelReplaceOrderReq{
+		OrderReq: binance.OrderReq{
+			Symbol:   "BTCUSDT",
+			Side:     binance.OrderSideSell,
+			Type:     binance.OrderTypeLimit,
+			Quantity: "1",
+			Price:    "0.1",
+		},
+		CancelOrderID:              5,
+		CancelRestrictions:         binance.CancelRestrictionsOnlyNew,
+		OrderRateLimitExceededMode: binance.OrderRateLimitExceededModeCancelOnly,
+	}
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("ONLY_NEW", values.Get("cancelRestrictions"))
+		s.Require().Equal("CANCEL_ONLY", values.Get("orderRateLimitExceededMode"))
+
+		return nil, &binance.APIError{
+			Code: binance.ErrCodeCancelReplacePartiallyFailed,
+			Msg:  "Order cancel-replace partially failed.",
+			Data: []byte(`{"cancelResult":"SUCCESS","newOrderResult":"FAILURE",
+				"cancelResponse":{"symbol":"BTCUSDT","orderId":5,"status":"CANCELED"},
+				"newOrderResponse":{"code":-2010,"msg":"Order would immediately match and take."}}`),
+		}
+	}
+	resp, err := s.api.CancelReplaceOrder(req)
+	var replaceErr *binance.CancelReplaceError
+	s.Require().ErrorAs(err, &replaceErr)
+	s.Require().Equal(binance.ErrCodeCancelReplacePartiallyFailed, replaceErr.Code)
+	s.Require().Same(resp, replaceErr.Result)
+	s.Require().True(resp.CancelSucceeded())
+	s.Require().False(resp.NewOrderSucceeded())
+	s.Require().Equal(binance.OrderStatusCanceled, resp.CancelResponse.Status)
+	s.Require().Nil(resp.CancelError)
+	s.Require().Nil(resp.NewOrderResponse)
+	s.Require().Equal(binance.ErrCodeNewOrderReject, resp.NewOrderError.Code)
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		return nil, &binance.APIError{
+			Code: binance.ErrCodeCancelReplaceFailed,
+			Msg:  "Order cancel-replace failed.",
+			Data: []byte(`{"cancelResult":"FAILURE","newOrderResult":"NOT_ATTEMPTED",
+				"cancelResponse":{"code":-2011,"msg":"Unknown order sent."},"newOrderResponse":null}`),
+		}
+	}
+	resp, err = s.api.CancelReplaceOrder(req)
+	s.Require().ErrorAs(err, &replaceErr)
+	s.Require().False(resp.CancelSucceeded())
+	s.Require().Equal(binance.CancelReplaceResultNotAttempted, resp.NewOrderResult)
+	s.Require().Equal(-2011, resp.CancelError.Code)
+	s.Require().Nil(resp.NewOrderError)
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		return nil, &binance.APIError{Code: binance.ErrCodeTooManyRequests}
+	}
+	resp, err = s.api.CancelReplaceOrder(req)
+	s.Require().Nil(resp)
+	s.Require().IsType(&binance.APIError{}, err)
 }
 
 func (s *mockedTestSuite) TestDataStream() {
@@ -510,24 +594,24 @@ func (s *mockedTestSuite) TestAllOrders() {
 		expected = append(expected, &binance.QueryOrder{
 			Symbol:              req.Symbol,
 			OrderID:             req.OrderID,
-			Price:               "0.1",
-			OrigQty:             "1",
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: "1",
+			Price:               mustDecimal("0.1"),
+			OrigQty:             mustDecimal("1"),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal("1"),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         binance.TimeInForceGTC,
 			Type:                binance.OrderTypeLimit,
 			Side:                binance.OrderSideSell,
 			Time:                rand.Uint64(),
 			UpdateTime:          rand.Uint64(),
-			OrigQuoteOrderQty:   "1",
+			OrigQuoteOrderQty:   mustDecimal("1"),
 		})
 		return json.Marshal(expected)
 	}
 
 	actual, e := s.api.AllOrders(&binance.AllOrdersReq{Symbol: "SNMBTC"})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expected, actual)
+	s.requireEqualJSON(expected, actual)
 }
 
 func (s *mockedTestSuite) TestOpenOrders() {
@@ -538,24 +622,24 @@ func (s *mockedTestSuite) TestOpenOrders() {
 		expected = append(expected, &binance.QueryOrder{
 			Symbol:              req.Symbol,
 			OrderID:             rand.Uint64(),
-			Price:               "0.1",
-			OrigQty:             "1",
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: "1",
+			Price:               mustDecimal("0.1"),
+			OrigQty:             mustDecimal("1"),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal("1"),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         binance.TimeInForceGTC,
 			Type:                binance.OrderTypeLimit,
 			Side:                binance.OrderSideSell,
 			Time:                rand.Uint64(),
 			UpdateTime:          rand.Uint64(),
-			OrigQuoteOrderQty:   "1",
+			OrigQuoteOrderQty:   mustDecimal("1"),
 		})
 		return json.Marshal(expected)
 	}
 
 	actual, e := s.api.OpenOrders(&binance.OpenOrdersReq{Symbol: "SNMBTC"})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expected, actual)
+	s.requireEqualJSON(expected, actual)
 }
 
 func (s *mockedTestSuite) TestCancelOpenOrders() {
@@ -566,10 +650,10 @@ func (s *mockedTestSuite) TestCancelOpenOrders() {
 		expected = append(expected, &binance.CancelOrder{
 			Symbol:              req.Symbol,
 			OrderID:             rand.Uint64(),
-			Price:               "0.1",
-			OrigQty:             "1",
-			ExecutedQty:         "0",
-			CummulativeQuoteQty: "1",
+			Price:               mustDecimal("0.1"),
+			OrigQty:             mustDecimal("1"),
+			ExecutedQty:         mustDecimal("0"),
+			CummulativeQuoteQty: mustDecimal("1"),
 			Status:              binance.OrderStatusNew,
 			TimeInForce:         binance.TimeInForceGTC,
 			Type:                binance.OrderTypeLimit,
@@ -580,7 +664,7 @@ func (s *mockedTestSuite) TestCancelOpenOrders() {
 
 	actual, e := s.api.CancelOpenOrders(&binance.CancelOpenOrdersReq{Symbol: "SNMBTC"})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expected, actual)
+	s.requireEqualJSON(expected, actual)
 }
 
 func (s *mockedTestSuite) TestAccount() {
@@ -592,14 +676,20 @@ func (s *mockedTestSuite) TestAccount() {
 			TakerCommission:  15,
 			BuyerCommission:  0,
 			SellerCommission: 0,
-			CanTrade:         true,
-			CanWithdraw:      true,
-			CanDeposit:       true,
-			AccountType:      binance.AccountTypeSpot,
+			CommissionRates: binance.CommissionRates{
+				Maker:  mustDecimal("0.00150000"),
+				Taker:  mustDecimal("0.00150000"),
+				Buyer:  mustDecimal("0.00000000"),
+				Seller: mustDecimal("0.00000000"),
+			},
+			CanTrade:    true,
+			CanWithdraw: true,
+			CanDeposit:  true,
+			AccountType: binance.AccountTypeSpot,
 			Balances: []*binance.Balance{{
 				Asset:  "SNM",
-				Free:   "1",
-				Locked: "",
+				Free:   mustDecimal("1"),
+				Locked: mustDecimal(""),
 			}},
 		}
 		return json.Marshal(expected)
@@ -607,22 +697,29 @@ func (s *mockedTestSuite) TestAccount() {
 
 	actual, e := s.api.Account()
 	s.Require().NoError(e)
-	s.Require().EqualValues(expected, actual)
+	s.requireEqualJSON(expected, actual)
 }
 
 func (s *mockedTestSuite) TestAccountTrades() {
-	var expected *binance.AccountTrades
+	var expected []*binance.AccountTrades
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
 		s.Require().IsType(&binance.AccountTradesReq{}, data)
 		req := data.(*binance.AccountTradesReq)
-		expected = &binance.AccountTrades{
+		expected = []*binance.AccountTrades{{
 			Symbol:   req.Symbol,
 			OrderID:  rand.Uint64(),
-			QuoteQty: "1",
-			Price:    "0.1",
-			Qty:      "1",
+			QuoteQty: mustDecimal("1"),
+			Price:    mustDecimal("0.1"),
+			Qty:      mustDecimal("1"),
 			Time:     rand.Uint64(),
-		}
+		}, {
+			Symbol:   req.Symbol,
+			OrderID:  rand.Uint64(),
+			QuoteQty: mustDecimal("2"),
+			Price:    mustDecimal("0.2"),
+			Qty:      mustDecimal("1"),
+			Time:     rand.Uint64(),
+		}}
 		return json.Marshal(expected)
 	}
 
@@ -630,5 +727,5 @@ func (s *mockedTestSuite) TestAccountTrades() {
 		Symbol: "SNMBTC",
 	})
 	s.Require().NoError(e)
-	s.Require().EqualValues(expected, actual)
+	s.requireEqualJSON(expected, actual)
 }
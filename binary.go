@@ -0,0 +1,240 @@
+package binance
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/xenking/decimal"
+)
+
+// Binary encoding is a compact format for storage and IPC.
+// Integers are varints, decimals are encoded as zigzag varint exponent followed by
+// uvarint header holding coefficient length in bytes and sign, and big endian coefficient bytes.
+// The scale of decimals is preserved, so FormatDecimal returns the same string after decoding.
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (b *Klines) MarshalBinary() ([]byte, error) {
+	return b.AppendBinary(make([]byte, 0, 96))
+}
+
+// AppendBinary appends binary encoded klines to buf
+func (b *Klines) AppendBinary(buf []byte) ([]byte, error) {
+	buf = appendUvarint(buf, b.OpenTime)
+	buf = appendUvarint(buf, b.CloseTime)
+	buf = appendVarint(buf, int64(b.Trades))
+	buf = appendBinaryDecimal(buf, b.OpenPrice)
+	buf = appendBinaryDecimal(buf, b.High)
+	buf = appendBinaryDecimal(buf, b.Low)
+	buf = appendBinaryDecimal(buf, b.ClosePrice)
+	buf = appendBinaryDecimal(buf, b.Volume)
+	buf = appendBinaryDecimal(buf, b.QuoteAssetVolume)
+	buf = appendBinaryDecimal(buf, b.TakerBuyBaseAssetVolume)
+	buf = appendBinaryDecimal(buf, b.TakerBuyQuoteAssetVolume)
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (b *Klines) UnmarshalBinary(data []byte) error {
+	if b == nil {
+		return ErrNilUnmarshal
+	}
+	r := binaryReader{data: data}
+	b.decode(&r)
+
+	return r.finish()
+}
+
+func (b *Klines) decode(r *binaryReader) {
+	b.OpenTime = r.uvarint()
+	b.CloseTime = r.uvarint()
+	b.Trades = int(r.varint())
+	b.OpenPrice = r.decimal()
+	b.High = r.decimal()
+	b.Low = r.decimal()
+	b.ClosePrice = r.decimal()
+	b.Volume = r.decimal()
+	b.QuoteAssetVolume = r.decimal()
+	b.TakerBuyBaseAssetVolume = r.decimal()
+	b.TakerBuyQuoteAssetVolume = r.decimal()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (b *DepthElem) MarshalBinary() ([]byte, error) {
+	return b.AppendBinary(make([]byte, 0, 24))
+}
+
+// AppendBinary appends binary encoded depth element to buf
+func (b *DepthElem) AppendBinary(buf []byte) ([]byte, error) {
+	buf = appendBinaryDecimal(buf, b.Price)
+
+	return appendBinaryDecimal(buf, b.Quantity), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (b *DepthElem) UnmarshalBinary(data []byte) error {
+	if b == nil {
+		return ErrNilUnmarshal
+	}
+	r := binaryReader{data: data}
+	b.decode(&r)
+
+	return r.finish()
+}
+
+func (b *DepthElem) decode(r *binaryReader) {
+	b.Price = r.decimal()
+	b.Quantity = r.decimal()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (d *Depth) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, 16+24*(len(d.Bids)+len(d.Asks))))
+}
+
+// AppendBinary appends binary encoded depth to buf
+func (d *Depth) AppendBinary(buf []byte) ([]byte, error) {
+	buf = appendVarint(buf, int64(d.LastUpdateID))
+	for _, side := range [][]DepthElem{d.Bids, d.Asks} {
+		buf = appendUvarint(buf, uint64(len(side)))
+		for i := range side {
+			buf, _ = side[i].AppendBinary(buf)
+		}
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (d *Depth) UnmarshalBinary(data []byte) error {
+	if d == nil {
+		return ErrNilUnmarshal
+	}
+	r := binaryReader{data: data}
+	d.LastUpdateID = int(r.varint())
+	d.Bids = r.depthElems()
+	d.Asks = r.depthElems()
+
+	return r.finish()
+}
+
+func appendBinaryDecimal(buf []byte, d decimal.Decimal) []byte {
+	buf = appendVarint(buf, int64(d.Exponent()))
+	c := d.Coefficient()
+	abs := c.Bytes()
+	header := uint64(len(abs)) << 1
+	if c.Sign() < 0 {
+		header |= 1
+	}
+	buf = appendUvarint(buf, header)
+
+	return append(buf, abs...)
+}
+
+// binaryReader keeps the first decoding error, so fields can be decoded without checks
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) fail() {
+	if r.err == nil {
+		r.err = ErrInvalidBinary
+	}
+	r.data = nil
+}
+
+func (r *binaryReader) finish() error {
+	if r.err == nil && len(r.data) > 0 {
+		return ErrInvalidBinary
+	}
+
+	return r.err
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+
+		return 0
+	}
+	r.data = r.data[n:]
+
+	return v
+}
+
+func (r *binaryReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail()
+
+		return 0
+	}
+	r.data = r.data[n:]
+
+	return v
+}
+
+func (r *binaryReader) decimal() decimal.Decimal {
+	exp := r.varint()
+	header := r.uvarint()
+	size := header >> 1
+	if r.err != nil || exp < -1<<31 || exp >= 1<<31 || size > uint64(len(r.data)) {
+		r.fail()
+
+		return decimal.Decimal{}
+	}
+	abs := r.data[:size]
+	r.data = r.data[size:]
+
+	// coefficients up to 7 bytes fit int64 without big.Int parsing
+	if size < 8 {
+		var v int64
+		for _, c := range abs {
+			v = v<<8 | int64(c)
+		}
+		if header&1 == 1 {
+			v = -v
+		}
+
+		return decimal.New(v, int32(exp))
+	}
+	c := new(big.Int).SetBytes(abs)
+	if header&1 == 1 {
+		c.Neg(c)
+	}
+
+	return decimal.NewFromBigInt(c, int32(exp))
+}
+
+func (r *binaryReader) depthElems() []DepthElem {
+	n := r.uvarint()
+	// every element takes at least 4 bytes
+	if r.err != nil || n > uint64(len(r.data))/4 {
+		r.fail()
+
+		return nil
+	}
+	res := make([]DepthElem, n)
+	for i := range res {
+		res[i].decode(r)
+	}
+
+	return res
+}
+
+// appendUvarint and appendVarint replace binary.AppendUvarint and binary.AppendVarint missing in go 1.17
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+
+	return append(buf, tmp[:n]...)
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+
+	return append(buf, tmp[:n]...)
+}
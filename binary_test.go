@@ -0,0 +1,86 @@
+package binance_test
+
+import (
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ugi1/binance-api"
+)
+
+const testKlinesJSON = `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0"]`
+
+func TestKlinesEncoding(t *testing.T) {
+	k := &binance.Klines{}
+	require.NoError(t, json.Unmarshal([]byte(testKlinesJSON), k))
+
+	data, err := json.Marshal(k)
+	require.NoError(t, err)
+	require.JSONEq(t, testKlinesJSON, string(data))
+
+	bin, err := k.MarshalBinary()
+	require.NoError(t, err)
+	decoded := &binance.Klines{}
+	require.NoError(t, decoded.UnmarshalBinary(bin))
+	data, err = json.Marshal(decoded)
+	require.NoError(t, err)
+	require.JSONEq(t, testKlinesJSON, string(data))
+
+	require.ErrorIs(t, decoded.UnmarshalBinary(bin[:len(bin)-1]), binance.ErrInvalidBinary)
+	require.ErrorIs(t, decoded.UnmarshalBinary(append(bin, 0)), binance.ErrInvalidBinary)
+}
+
+func TestDepthEncoding(t *testing.T) {
+	raw := `{"lastUpdateId":1027024,"bids":[["4.00000000","431.00000000"],["3.99000000","0.00100000"]],` +
+		`"asks":[["4.00000200","12.00000000"],["123456789012345678901.123456789","-0.5"]]}`
+	depth := &binance.Depth{}
+	require.NoError(t, json.Unmarshal([]byte(raw), depth))
+
+	data, err := json.Marshal(depth)
+	require.NoError(t, err)
+	require.JSONEq(t, raw, string(data))
+
+	bin, err := depth.MarshalBinary()
+	require.NoError(t, err)
+	decoded := &binance.Depth{}
+	require.NoError(t, decoded.UnmarshalBinary(bin))
+	data, err = json.Marshal(decoded)
+	require.NoError(t, err)
+	require.JSONEq(t, raw, string(data))
+
+	elem := depth.Bids[1]
+	bin, err = elem.AppendBinary([]byte{0xff})
+	require.NoError(t, err)
+	decodedElem := &binance.DepthElem{}
+	require.NoError(t, decodedElem.UnmarshalBinary(bin[1:]))
+	require.Equal(t, "0.00100000", binance.FormatDecimal(decodedElem.Quantity))
+
+	require.ErrorIs(t, decoded.UnmarshalBinary([]byte{0x02, 0xff, 0xff, 0xff, 0xff, 0x0f}), binance.ErrInvalidBinary)
+}
+
+func BenchmarkKlinesEncoding(b *testing.B) {
+	k := &binance.Klines{}
+	require.NoError(b, json.Unmarshal([]byte(testKlinesJSON), k))
+	bin, err := k.MarshalBinary()
+	require.NoError(b, err)
+	b.Run("MarshalBinary", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 128)
+		for i := 0; i < b.N; i++ {
+			buf, _ = k.AppendBinary(buf[:0])
+		}
+	})
+	b.Run("UnmarshalBinary", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = k.UnmarshalBinary(bin)
+		}
+	})
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = k.MarshalJSON()
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package binance
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+const (
+	// MaxClientOrderIDLength is the maximal length of the client order id accepted by Binance
+	MaxClientOrderIDLength = 36
+	// MaxClientOrderIDPart is the maximal length of the client order id prefix and strategy tag
+	MaxClientOrderIDPart = 8
+	// DefaultSubmitAttempts is how many times SubmitOrder sends the order when its status is unknown
+	DefaultSubmitAttempts = 3
+	// DefaultSubmitQueries is how many times SubmitOrder queries the order with unknown status before sending it again
+	DefaultSubmitQueries = 3
+	// DefaultSubmitWait is the pause before the first query of the order with unknown status
+	DefaultSubmitWait = 500 * time.Millisecond
+
+	clientOrderIDSeparator    = '-'
+	clientOrderIDRandomLength = 5
+	clientOrderIDRandomRange  = 36 * 36 * 36 * 36 * 36
+)
+
+// ClientOrderIDGenerator generates unique client order ids in the form prefix-tag-session-sequence.
+// The session is the generator creation time in seconds followed by 5 random characters,
+// so ids of generators created at the same time or after a restart don't collide,
+// and the sequence is incremented for every id. All of them are base 36 encoded to fit the 36 characters limit
+type ClientOrderIDGenerator struct {
+	prefix string
+	seq    uint64
+}
+
+// NewClientOrderIDGenerator creates a generator of ids with the prefix and the strategy tag.
+// Both can be empty or have up to 8 letters, digits or underscores
+func NewClientOrderIDGenerator(prefix, tag string) (*ClientOrderIDGenerator, error) {
+	if !validClientOrderIDPart(prefix) || !validClientOrderIDPart(tag) {
+		return nil, ErrInvalidClientOrderID
+	}
+	random, err := rand.Int(rand.Reader, big.NewInt(clientOrderIDRandomRange))
+	if err != nil {
+		return nil, errors.Wrap(err, "random session")
+	}
+	session := strconv.FormatInt(time.Now().Unix(), 36) +
+		fmt.Sprintf("%0*s", clientOrderIDRandomLength, strconv.FormatInt(random.Int64(), 36))
+
+	return &ClientOrderIDGenerator{
+		prefix: prefix + string(clientOrderIDSeparator) + tag + string(clientOrderIDSeparator) +
+			session + string(clientOrderIDSeparator),
+	}, nil
+}
+
+// Next returns the next client order id, it is safe for concurrent use.
+// It returns ErrClientOrderIDExhausted when the id would exceed MaxClientOrderIDLength
+func (g *ClientOrderIDGenerator) Next() (string, error) {
+	seq := atomic.AddUint64(&g.seq, 1)
+	id := g.prefix + strconv.FormatUint(seq, 36)
+	if len(id) > MaxClientOrderIDLength {
+		return "", ErrClientOrderIDExhausted
+	}
+
+	return id, nil
+}
+
+// Owns reports whether the id was generated by the generator
+func (g *ClientOrderIDGenerator) Owns(id string) bool {
+	return strings.HasPrefix(id, g.prefix)
+}
+
+// ClientOrderID is the parsed client order id generated by ClientOrderIDGenerator
+type ClientOrderID struct {
+	Prefix   string
+	Tag      string
+	Session  uint64 // Session is the generator creation time in seconds
+	Random   string // Random is the random part of the session
+	Sequence uint64
+}
+
+// ParseClientOrderID parses the id generated by ClientOrderIDGenerator
+func ParseClientOrderID(id string) (ClientOrderID, error) {
+	parts := strings.Split(id, string(clientOrderIDSeparator))
+	if len(id) > MaxClientOrderIDLength || len(parts) != 4 ||
+		!validClientOrderIDPart(parts[0]) || !validClientOrderIDPart(parts[1]) ||
+		len(parts[2]) <= clientOrderIDRandomLength {
+		return ClientOrderID{}, ErrInvalidClientOrderID
+	}
+	split := len(parts[2]) - clientOrderIDRandomLength
+	session, err := strconv.ParseUint(parts[2][:split], 36, 64)
+	if err != nil {
+		return ClientOrderID{}, errors.Wrapf(ErrInvalidClientOrderID, "session: %v", err)
+	}
+	if _, err = strconv.ParseUint(parts[2][split:], 36, 64); err != nil {
+		return ClientOrderID{}, errors.Wrapf(ErrInvalidClientOrderID, "session: %v", err)
+	}
+	seq, err := strconv.ParseUint(parts[3], 36, 64)
+	if err != nil {
+		return ClientOrderID{}, errors.Wrapf(ErrInvalidClientOrderID, "sequence: %v", err)
+	}
+
+	return ClientOrderID{
+		Prefix:   parts[0],
+		Tag:      parts[1],
+		Session:  session,
+		Random:   parts[2][split:],
+		Sequence: seq,
+	}, nil
+}
+
+func validClientOrderIDPart(s string) bool {
+	if len(s) > MaxClientOrderIDPart {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OrderSubmitter places orders at most once using their client order ids.
+// When the order status is unknown because of a transport failure or a backend timeout,
+// the order may still be in flight, so it's queried by the client order id after a pause
+// which is doubled before every next query. The order is sent again only when none of the queries find it
+type OrderSubmitter struct {
+	Attempts int           // Attempts is the maximal number of times the order is sent
+	Queries  int           // Queries is the number of queries of the order with unknown status before it's sent again
+	Wait     time.Duration // Wait is the pause before the first query, it's doubled before every next one
+}
+
+// NewOrderSubmitter creates a submitter with default attempts and waits
+func NewOrderSubmitter() *OrderSubmitter {
+	return &OrderSubmitter{
+		Attempts: DefaultSubmitAttempts,
+		Queries:  DefaultSubmitQueries,
+		Wait:     DefaultSubmitWait,
+	}
+}
+
+// Submit places the order at most once, the order must have the client order id.
+// Calling Submit again with the same request after an error is safe
+func (s *OrderSubmitter) Submit(c *Client, req *OrderReq) (*OrderRespResult, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.NewClientOrderID == "" {
+		return nil, ErrInvalidClientOrderID
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	var placeErr, queryErr error
+	for attempt := 0; attempt == 0 || attempt < s.Attempts; attempt++ {
+		var resp *OrderRespResult
+		resp, placeErr = c.NewOrderResult(req)
+		if !isUnknownOrderStatus(placeErr) {
+			return resp, placeErr
+		}
+		var order *QueryOrder
+		order, queryErr = s.query(c, req)
+		if queryErr == nil {
+			return order.result(), nil
+		}
+		if !isNoSuchOrder(queryErr) {
+			return nil, queryErr
+		}
+	}
+
+	// the placement error caused the retries, the order was just not found
+	return nil, errors.Wrapf(placeErr, "order %s not found: %v", req.NewClientOrderID, queryErr)
+}
+
+// query waits for the order with unknown status to show up, it returns no such order error
+// when none of the queries find the order
+func (s *OrderSubmitter) query(c *Client, req *OrderReq) (*QueryOrder, error) {
+	wait := s.Wait
+	var err error
+	for query := 0; query == 0 || query < s.Queries; query++ {
+		time.Sleep(wait)
+		wait *= 2
+		var order *QueryOrder
+		order, err = c.QueryOrder(&QueryOrderReq{
+			Symbol:            req.Symbol,
+			OrigClientOrderID: req.NewClientOrderID,
+		})
+		if err == nil || !isNoSuchOrder(err) {
+			return order, err
+		}
+	}
+
+	return nil, err
+}
+
+// SubmitOrder places the order at most once using its client order id with the default OrderSubmitter
+func (c *Client) SubmitOrder(req *OrderReq) (*OrderRespResult, error) {
+	return NewOrderSubmitter().Submit(c, req)
+}
+
+func isNoSuchOrder(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeNoSuchOrder
+}
+
+// isUnknownOrderStatus reports whether the order could have been placed despite the error
+func isUnknownOrderStatus(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	return apiErr.Code == ErrCodeUnexpectedResponse || apiErr.Code == ErrCodeTimeout
+}
+
+// result converts the queried order into the order placement result
+func (o *QueryOrder) result() *OrderRespResult {
+	return &OrderRespResult{
+		Symbol:                  o.Symbol,
+		OrderID:                 o.OrderID,
+		OrderListID:             int(o.OrderListID),
+		ClientOrderID:           o.ClientOrderID,
+		TransactTime:            o.Time,
+		Price:                   o.Price,
+		OrigQty:                 o.OrigQty,
+		ExecutedQty:             o.ExecutedQty,
+		CummulativeQuoteQty:     o.CummulativeQuoteQty,
+		Status:                  o.Status,
+		TimeInForce:             string(o.TimeInForce),
+		Type:                    o.Type,
+		Side:                    o.Side,
+		StrategyID:              o.StrategyID,
+		StrategyType:            o.StrategyType,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		PreventedMatchID:        o.PreventedMatchID,
+		PreventedQuantity:       o.PreventedQuantity,
+	}
+}
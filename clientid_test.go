@@ -0,0 +1,166 @@
+package binance_test
+
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) TestClientOrderIDGenerator() {
+	_, err := binance.NewClientOrderIDGenerator("prefix-1", "tag")
+	s.Require().ErrorIs(err, binance.ErrInvalidClientOrderID)
+	_, err = binance.NewClientOrderIDGenerator("prefix", "too_long_tag")
+	s.Require().ErrorIs(err, binance.ErrInvalidClientOrderID)
+
+	gen, err := binance.NewClientOrderIDGenerator("prefix_1", "strategy")
+	s.Require().NoError(err)
+	first, err := gen.Next()
+	s.Require().NoError(err)
+	second, err := gen.Next()
+	s.Require().NoError(err)
+	s.Require().NotEqual(first, second)
+	s.Require().LessOrEqual(len(first), binance.MaxClientOrderIDLength)
+	s.Require().True(gen.Owns(first))
+
+	id, err := binance.ParseClientOrderID(second)
+	s.Require().NoError(err)
+	s.Require().Equal("prefix_1", id.Prefix)
+	s.Require().Equal("strategy", id.Tag)
+	s.Require().InDelta(time.Now().Unix(), id.Session, 5)
+	s.Require().Len(id.Random, 5)
+	s.Require().EqualValues(2, id.Sequence)
+
+	other, err := binance.NewClientOrderIDGenerator("prefix_1", "other")
+	s.Require().NoError(err)
+	s.Require().False(other.Owns(first))
+
+	// generators created at the same time have different sessions
+	same, err := binance.NewClientOrderIDGenerator("prefix_1", "strategy")
+	s.Require().NoError(err)
+	sameFirst, err := same.Next()
+	s.Require().NoError(err)
+	s.Require().NotEqual(first, sameFirst)
+	s.Require().False(same.Owns(first))
+
+	// the longest prefix and tag still leave at least 5 characters to the sequence
+	s.Require().LessOrEqual(len(first)-1, binance.MaxClientOrderIDLength-5)
+
+	_, err = binance.ParseClientOrderID("web_123456")
+	s.Require().ErrorIs(err, binance.ErrInvalidClientOrderID)
+}
+
+func (s *mockedTestSuite) TestSubmitOrder() {
+	req := binance.NewLimitOrderReq("BTCUSDT", binance.OrderSideBuy, "", mustDecimal("1"), mustDecimal("10")).
+		WithClientOrderID("p-s-1-1")
+	submitter := &binance.OrderSubmitter{Attempts: 3, Queries: 2, Wait: time.Millisecond}
+	var placed, queried int
+	exists := false
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointOrder:
+			if _, ok := data.(*binance.QueryOrderReq); ok {
+				queried++
+				if !exists {
+					return nil, &binance.APIError{Code: binance.ErrCodeNoSuchOrder}
+				}
+
+				return json.Marshal(&binance.QueryOrder{Symbol: "BTCUSDT", OrderID: 5, ClientOrderID: "p-s-1-1", Status: binance.OrderStatusNew})
+			}
+			placed++
+			if placed == 1 {
+				return nil, errors.New("read timeout")
+			}
+			exists = true
+
+			return json.Marshal(&binance.OrderRespResult{Symbol: "BTCUSDT", OrderID: 5, ClientOrderID: "p-s-1-1"})
+		default:
+			s.FailNow("unexpected endpoint", endpoint)
+		}
+
+		return nil, nil
+	}
+
+	resp, err := submitter.Submit(s.api, req)
+	s.Require().NoError(err)
+	s.Require().EqualValues(5, resp.OrderID)
+	s.Require().Equal(2, placed)
+	s.Require().Equal(2, queried)
+
+	// the order was placed despite the timeout, it mustn't be sent again
+	placed, queried = 0, 0
+	exists = true
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		if _, ok := data.(*binance.QueryOrderReq); ok {
+			queried++
+
+			return json.Marshal(&binance.QueryOrder{Symbol: "BTCUSDT", OrderID: 7, ClientOrderID: "p-s-1-1", Status: binance.OrderStatusFilled})
+		}
+		placed++
+
+		return nil, &binance.APIError{Code: binance.ErrCodeTimeout}
+	}
+	resp, err = submitter.Submit(s.api, req)
+	s.Require().NoError(err)
+	s.Require().EqualValues(7, resp.OrderID)
+	s.Require().Equal(binance.OrderStatusFilled, resp.Status)
+	s.Require().Equal(1, placed)
+	s.Require().Equal(1, queried)
+
+	// the order in flight shows up only after the first query, it mustn't be sent again
+	placed, queried = 0, 0
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		if _, ok := data.(*binance.QueryOrderReq); ok {
+			queried++
+			if queried == 1 {
+				return nil, &binance.APIError{Code: binance.ErrCodeNoSuchOrder}
+			}
+
+			return json.Marshal(&binance.QueryOrder{Symbol: "BTCUSDT", OrderID: 9, ClientOrderID: "p-s-1-1", Status: binance.OrderStatusNew})
+		}
+		placed++
+
+		return nil, &binance.APIError{Code: binance.ErrCodeUnexpectedResponse}
+	}
+	resp, err = submitter.Submit(s.api, req)
+	s.Require().NoError(err)
+	s.Require().EqualValues(9, resp.OrderID)
+	s.Require().Equal(1, placed)
+	s.Require().Equal(2, queried)
+
+	// the order isn't found after all attempts
+	placed, queried = 0, 0
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		if _, ok := data.(*binance.QueryOrderReq); ok {
+			queried++
+
+			return nil, &binance.APIError{Code: binance.ErrCodeNoSuchOrder}
+		}
+		placed++
+
+		return nil, &binance.APIError{Code: binance.ErrCodeTimeout}
+	}
+	_, err = submitter.Submit(s.api, req)
+	// the timeout is returned rather than the query error
+	var apiErr *binance.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrCodeTimeout, apiErr.Code)
+	s.Require().Contains(err.Error(), "not found")
+	s.Require().Equal(3, placed)
+	s.Require().Equal(6, queried)
+
+	// rejected orders are returned as is
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().IsType(&binance.OrderReq{}, data)
+
+		return nil, &binance.APIError{Code: binance.ErrCodeNewOrderReject}
+	}
+	_, err = s.api.SubmitOrder(req)
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrCodeNewOrderReject, apiErr.Code)
+
+	_, err = s.api.SubmitOrder(binance.NewMarketOrderReq("BTCUSDT", binance.OrderSideBuy, mustDecimal("1")))
+	s.Require().ErrorIs(err, binance.ErrInvalidClientOrderID)
+}
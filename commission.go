@@ -0,0 +1,202 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+// CommissionRates are commission rates as fractions, e.g. 0.001 is 0.1%.
+// Maker or taker rate is added to the buyer or seller rate of a trade
+type CommissionRates struct {
+	Maker  decimal.Decimal `json:"maker"`
+	Taker  decimal.Decimal `json:"taker"`
+	Buyer  decimal.Decimal `json:"buyer"`
+	Seller decimal.Decimal `json:"seller"`
+}
+
+// Rate returns the rate of a trade of the side
+func (r CommissionRates) Rate(side OrderSide, maker bool) decimal.Decimal {
+	rate := r.Taker
+	if maker {
+		rate = r.Maker
+	}
+	if side == OrderSideBuy {
+		return rate.Add(r.Buyer)
+	}
+
+	return rate.Add(r.Seller)
+}
+
+// CommissionDiscount is the discount of standard commissions paid in the discount asset
+type CommissionDiscount struct {
+	EnabledForAccount bool            `json:"enabledForAccount"`
+	EnabledForSymbol  bool            `json:"enabledForSymbol"`
+	DiscountAsset     string          `json:"discountAsset"`
+	Discount          decimal.Decimal `json:"discount"` // Discount is the multiplier of standard rates, e.g. 0.75
+}
+
+type AccountCommissionReq struct {
+	Symbol string `url:"symbol"`
+}
+
+// AccountCommission is commission rates of the account for the symbol
+type AccountCommission struct {
+	Symbol             string             `json:"symbol"`
+	StandardCommission CommissionRates    `json:"standardCommission"`
+	SpecialCommission  CommissionRates    `json:"specialCommission"`
+	TaxCommission      CommissionRates    `json:"taxCommission"`
+	Discount           CommissionDiscount `json:"discount"`
+}
+
+// AccountCommission get current account commission rates of the symbol
+func (c *Client) AccountCommission(req *AccountCommissionReq) (*AccountCommission, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccountCommission, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AccountCommission{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// DiscountEnabled reports whether commissions paid in the discount asset are discounted
+func (c *AccountCommission) DiscountEnabled() bool {
+	return c.Discount.EnabledForAccount && c.Discount.EnabledForSymbol && c.Discount.DiscountAsset != ""
+}
+
+// Rate returns the total commission rate of a trade of the side.
+// Standard rates are discounted when discounted is set and the discount is enabled,
+// special and tax rates are never discounted
+func (c *AccountCommission) Rate(side OrderSide, maker, discounted bool) decimal.Decimal {
+	standard := c.StandardCommission.Rate(side, maker)
+	if discounted && c.DiscountEnabled() {
+		standard = standard.Mul(c.Discount.Discount)
+	}
+
+	return standard.
+		Add(c.SpecialCommission.Rate(side, maker)).
+		Add(c.TaxCommission.Rate(side, maker))
+}
+
+// OrderFees computes fees of prospective orders of a symbol
+type OrderFees struct {
+	Commission *AccountCommission
+	BaseAsset  string
+	QuoteAsset string
+	// BurnBNB is set when paying commissions with the discount asset (BNB) is enabled for the account.
+	// The discount asset is used only when the discount is enabled for the symbol too
+	BurnBNB bool
+	// DiscountPrice is the price of the discount asset in the quote asset,
+	// it's needed to compute the commission amount unless the discount asset is the base or quote asset
+	DiscountPrice decimal.Decimal
+}
+
+// NewOrderFees creates fees of orders of the symbol
+func NewOrderFees(commission *AccountCommission, info *SymbolInfo, burnBNB bool, discountPrice decimal.Decimal) *OrderFees {
+	return &OrderFees{
+		Commission:    commission,
+		BaseAsset:     info.BaseAsset,
+		QuoteAsset:    info.QuoteAsset,
+		BurnBNB:       burnBNB,
+		DiscountPrice: discountPrice,
+	}
+}
+
+// OrderFee is the expected commission of an order
+type OrderFee struct {
+	Rate decimal.Decimal // Rate is the total commission rate
+	// Asset is the commission asset, it's the received asset unless the discount asset is used
+	Asset string
+	// Amount is the commission in Asset, it's zero when the discount asset price is unknown
+	Amount decimal.Decimal
+	// QuoteAmount is the commission valued in the quote asset
+	QuoteAmount decimal.Decimal
+	// NetQty is the received quantity after commission, the base asset for buy and the quote asset for sell orders
+	NetQty decimal.Decimal
+}
+
+// discounted reports whether commissions are paid in the discount asset
+func (f *OrderFees) discounted() bool {
+	return f.BurnBNB && f.Commission.DiscountEnabled()
+}
+
+// Rate returns the total commission rate of the order
+func (f *OrderFees) Rate(side OrderSide, maker bool) decimal.Decimal {
+	return f.Commission.Rate(side, maker, f.discounted())
+}
+
+// Fee returns the expected commission of the order filled at price.
+// Remark: Binance takes the commission from the received asset when the discount asset balance is insufficient
+func (f *OrderFees) Fee(side OrderSide, maker bool, qty, price decimal.Decimal) OrderFee {
+	rate := f.Rate(side, maker)
+	quoteQty := qty.Mul(price)
+	fee := OrderFee{
+		Rate:        rate,
+		QuoteAmount: quoteQty.Mul(rate),
+	}
+	switch {
+	case f.discounted():
+		fee.Asset = f.Commission.Discount.DiscountAsset
+		if discountPrice := f.discountPrice(price); !discountPrice.IsZero() {
+			fee.Amount = fee.QuoteAmount.Div(discountPrice)
+		}
+		fee.NetQty = quoteQty
+		if side == OrderSideBuy {
+			fee.NetQty = qty
+		}
+	case side == OrderSideBuy:
+		fee.Asset = f.BaseAsset
+		fee.Amount = qty.Mul(rate)
+		fee.NetQty = qty.Sub(fee.Amount)
+	default:
+		fee.Asset = f.QuoteAsset
+		fee.Amount = fee.QuoteAmount
+		fee.NetQty = quoteQty.Sub(fee.Amount)
+	}
+
+	return fee
+}
+
+// discountPrice returns the price of the discount asset in the quote asset
+func (f *OrderFees) discountPrice(price decimal.Decimal) decimal.Decimal {
+	switch f.Commission.Discount.DiscountAsset {
+	case f.QuoteAsset:
+		return decimal.New(1, 0)
+	case f.BaseAsset:
+		return price
+	}
+
+	return f.DiscountPrice
+}
+
+// BreakEven returns the price closing the position opened at price without loss after both commissions.
+// The position is long when side is buy and short otherwise, closing is the opposite side.
+// The whole received quantity is sold when closing a long position, and the opened quantity
+// is bought back when closing a short one
+func (f *OrderFees) BreakEven(side OrderSide, openMaker, closeMaker bool, price decimal.Decimal) decimal.Decimal {
+	one := decimal.New(1, 0)
+	if side == OrderSideBuy {
+		open, closing := f.Rate(OrderSideBuy, openMaker), f.Rate(OrderSideSell, closeMaker)
+		if f.discounted() {
+			// price * (1 + open) = closePrice * (1 - closing)
+			return price.Mul(one.Add(open)).Div(one.Sub(closing))
+		}
+		// price = closePrice * (1 - open) * (1 - closing)
+		return price.Div(one.Sub(open).Mul(one.Sub(closing)))
+	}
+	open, closing := f.Rate(OrderSideSell, openMaker), f.Rate(OrderSideBuy, closeMaker)
+	if f.discounted() {
+		// price * (1 - open) = closePrice * (1 + closing)
+		return price.Mul(one.Sub(open)).Div(one.Add(closing))
+	}
+	// price * (1 - open) = closePrice / (1 - closing)
+	return price.Mul(one.Sub(open)).Mul(one.Sub(closing))
+}
@@ -0,0 +1,100 @@
+package binance_test
+
+import (
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+const accountCommissionResp = `{"symbol":"BTCUSDT",
+	"standardCommission":{"maker":"0.00100000","taker":"0.00200000","buyer":"0.00000000","seller":"0.00000000"},
+	"specialCommission":{"maker":"0.00000000","taker":"0.00000000","buyer":"0.00000000","seller":"0.00000000"},
+	"taxCommission":{"maker":"0.00000000","taker":"0.00000000","buyer":"0.00010000","seller":"0.00000000"},
+	"discount":{"enabledForAccount":true,"enabledForSymbol":true,"discountAsset":"BNB","discount":"0.75000000"}}`
+
+func (s *mockedTestSuite) accountCommission() *binance.AccountCommission {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointAccountCommission, endpoint)
+		s.Require().True(sign)
+		s.Require().Equal("BTCUSDT", data.(*binance.AccountCommissionReq).Symbol)
+
+		return []byte(accountCommissionResp), nil
+	}
+	resp, err := s.api.AccountCommission(&binance.AccountCommissionReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+
+	return resp
+}
+
+func (s *mockedTestSuite) requireDecimal(expected string, actual decimal.Decimal) {
+	s.Require().True(mustDecimal(expected).Equal(actual), "expected %s, got %s", expected, actual)
+}
+
+func (s *mockedTestSuite) TestAccountCommission() {
+	resp := s.accountCommission()
+	s.Require().Equal("BTCUSDT", resp.Symbol)
+	s.Require().Equal("BNB", resp.Discount.DiscountAsset)
+	s.Require().True(resp.DiscountEnabled())
+	s.requireDecimal("0.002", resp.StandardCommission.Taker)
+	s.requireDecimal("0.0011", resp.Rate(binance.OrderSideBuy, true, false))
+	s.requireDecimal("0.00085", resp.Rate(binance.OrderSideBuy, true, true))
+	s.requireDecimal("0.0015", resp.Rate(binance.OrderSideSell, false, true))
+
+	resp.Discount.EnabledForSymbol = false
+	s.Require().False(resp.DiscountEnabled())
+	s.requireDecimal("0.002", resp.Rate(binance.OrderSideSell, false, true))
+
+	_, err := s.api.AccountCommission(nil)
+	s.Require().ErrorIs(err, binance.ErrNilRequest)
+	_, err = s.api.AccountCommission(&binance.AccountCommissionReq{})
+	s.Require().ErrorIs(err, binance.ErrEmptySymbol)
+}
+
+func (s *mockedTestSuite) TestOrderFees() {
+	info := &binance.SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}
+	fees := binance.NewOrderFees(s.accountCommission(), info, false, decimal.Zero)
+
+	// the commission is taken from the received asset
+	fee := fees.Fee(binance.OrderSideBuy, false, mustDecimal("2"), mustDecimal("100"))
+	s.Require().Equal("BTC", fee.Asset)
+	s.requireDecimal("0.0021", fee.Rate)
+	s.requireDecimal("0.0042", fee.Amount)
+	s.requireDecimal("0.42", fee.QuoteAmount)
+	s.requireDecimal("1.9958", fee.NetQty)
+
+	fee = fees.Fee(binance.OrderSideSell, true, mustDecimal("2"), mustDecimal("100"))
+	s.Require().Equal("USDT", fee.Asset)
+	s.requireDecimal("0.2", fee.Amount)
+	s.requireDecimal("199.8", fee.NetQty)
+
+	// long: 100 = x * (1 - 0.0011) * (1 - 0.001)
+	s.requireDecimal("100.2103314647", fees.BreakEven(binance.OrderSideBuy, true, true, mustDecimal("100")).Round(10))
+	// short: 100 * (1 - 0.001) = x / (1 - 0.0011)
+	s.requireDecimal("99.79011", fees.BreakEven(binance.OrderSideSell, true, true, mustDecimal("100")))
+
+	// BNB pays the discounted commission and the received quantity isn't reduced
+	fees = binance.NewOrderFees(fees.Commission, info, true, mustDecimal("300"))
+	fee = fees.Fee(binance.OrderSideBuy, false, mustDecimal("2"), mustDecimal("100"))
+	s.Require().Equal("BNB", fee.Asset)
+	s.requireDecimal("0.0016", fee.Rate)
+	s.requireDecimal("0.32", fee.QuoteAmount)
+	s.requireDecimal("0.0010666666666667", fee.Amount)
+	s.requireDecimal("2", fee.NetQty)
+
+	fee = fees.Fee(binance.OrderSideSell, true, mustDecimal("2"), mustDecimal("100"))
+	s.requireDecimal("0.15", fee.QuoteAmount)
+	s.requireDecimal("200", fee.NetQty)
+
+	// long: 100 * (1 + 0.00085) = x * (1 - 0.00075)
+	s.requireDecimal("100.1601200901", fees.BreakEven(binance.OrderSideBuy, true, true, mustDecimal("100")).Round(10))
+	// short: 100 * (1 - 0.00075) = x * (1 + 0.00085)
+	s.requireDecimal("99.8401358845", fees.BreakEven(binance.OrderSideSell, true, true, mustDecimal("100")).Round(10))
+
+	// the discount asset is the quote asset
+	bnb := &binance.SymbolInfo{Symbol: "BTCBNB", BaseAsset: "BTC", QuoteAsset: "BNB"}
+	fee = binance.NewOrderFees(fees.Commission, bnb, true, decimal.Zero).
+		Fee(binance.OrderSideSell, true, mustDecimal("1"), mustDecimal("200"))
+	s.requireDecimal("0.15", fee.Amount)
+}
@@ -0,0 +1,77 @@
+package binance
+
+import (
+	"github.com/xenking/decimal"
+)
+
+// maxInt64Digits is the number of digits which always fits int64
+const maxInt64Digits = 18
+
+// ParseDecimal parses decimal number in Binance format like "0.00120000".
+// Numbers with up to 18 significant digits are parsed without intermediate strings,
+// the others fall back to decimal.NewFromString. It isn't allocation free, decimal.Decimal
+// always allocates its big.Int value. The scale of the input is preserved,
+// so FormatDecimal returns the original string.
+//
+// Remark: decimal fields of the models are decoded by decimal.Decimal.UnmarshalJSON which preserves
+// the scale too, but json.Marshal drops trailing zeros, e.g. "0.10000000" is marshaled as "0.1".
+// Use FormatDecimal to send decimals back in Binance format
+func ParseDecimal(b []byte) (decimal.Decimal, error) {
+	var (
+		value  int64
+		n      int
+		digits int
+		frac   int
+		point  bool
+		neg    bool
+	)
+	i := 0
+	if len(b) > 0 && (b[0] == '-' || b[0] == '+') {
+		neg = b[0] == '-'
+		i++
+	}
+	for ; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if digits == maxInt64Digits {
+				return decimal.NewFromString(b2s(b))
+			}
+			if value != 0 || c != '0' {
+				digits++
+			}
+			n++
+			value = value*10 + int64(c-'0')
+			if point {
+				frac++
+			}
+		case c == '.' && !point:
+			point = true
+		default:
+			return decimal.NewFromString(b2s(b))
+		}
+	}
+	if n == 0 {
+		return decimal.Decimal{}, ErrInvalidDecimal
+	}
+	if neg {
+		value = -value
+	}
+
+	return decimal.New(value, int32(-frac)), nil
+}
+
+// FormatDecimal formats d keeping its scale, so decimals parsed from Binance responses either by ParseDecimal
+// or by json.Unmarshal are formatted back exactly as they were received, e.g. "0.00120000"
+func FormatDecimal(d decimal.Decimal) string {
+	if exp := d.Exponent(); exp < 0 {
+		return d.StringFixed(-exp)
+	}
+
+	return d.String()
+}
+
+// AppendDecimal appends FormatDecimal result to dst
+func AppendDecimal(dst []byte, d decimal.Decimal) []byte {
+	return append(dst, FormatDecimal(d)...)
+}
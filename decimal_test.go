@@ -0,0 +1,92 @@
+package binance_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for _, in := range []string{
+		"0", "1", "-1", "0.1", "0.00100000", "60000.01000000", "123456789.12345678",
+		"1234567890123456789.123456789", "0.000000000000000000001", "100", "-0.5",
+	} {
+		d, err := binance.ParseDecimal([]byte(in))
+		require.NoError(t, err, in)
+		expected := decimal.RequireFromString(in)
+		require.True(t, expected.Equal(d), in)
+		require.Equal(t, expected.Exponent(), d.Exponent(), in)
+		require.Equal(t, in, binance.FormatDecimal(d))
+	}
+	for _, in := range []string{"", "-", ".", "1.2.3", "abc", "1e"} {
+		_, err := binance.ParseDecimal([]byte(in))
+		require.Error(t, err, in)
+	}
+	require.Equal(t, "x:0.10", string(binance.AppendDecimal([]byte("x:"), decimal.RequireFromString("0.10"))))
+}
+
+func TestDecimalModelsRoundTrip(t *testing.T) {
+	raw := `{"symbol":"BTCUSDT","bidPrice":"60000.01000000","bidQty":"0.00120000","askPrice":"60000.02000000","askQty":"3.00000000"}`
+	ticker := &binance.BookTicker{}
+	require.NoError(t, json.Unmarshal([]byte(raw), ticker))
+	require.True(t, ticker.BidPrice.Equal(decimal.RequireFromString("60000.01")))
+	require.Equal(t, "0.00120000", binance.FormatDecimal(ticker.BidQty))
+	require.Equal(t, "3.00000000", binance.FormatDecimal(ticker.AskQty))
+
+	order := &binance.QueryOrder{}
+	require.NoError(t, json.Unmarshal([]byte(`{"price":"0.10000000","origQty":"1.00000000","executedQty":"0.50000000"}`), order))
+	require.True(t, order.OrigQty.Sub(order.ExecutedQty).Equal(decimal.RequireFromString("0.5")))
+	require.Equal(t, "0.10000000", binance.FormatDecimal(order.Price))
+}
+
+func TestParseDecimalAllocs(t *testing.T) {
+	in := []byte("60000.01000000")
+	// the big.Int value of decimal.Decimal and its words
+	require.LessOrEqual(t, testing.AllocsPerRun(100, func() {
+		_, _ = binance.ParseDecimal(in)
+	}), float64(2))
+}
+
+func TestDecimalJSONRoundTrip(t *testing.T) {
+	raw := `{"id":28457,"price":"0.10000000","qty":"12.00000000","quoteQty":"1.20000000","time":1499865549590,` +
+		`"isBuyerMaker":true,"isBestMatch":true}`
+	trade := &binance.Trade{}
+	require.NoError(t, json.Unmarshal([]byte(raw), trade))
+
+	// FormatDecimal reproduces the original bytes
+	formatted := fmt.Sprintf(`{"id":%d,"price":"%s","qty":"%s","quoteQty":"%s","time":%d,"isBuyerMaker":%t,"isBestMatch":%t}`,
+		trade.ID, binance.FormatDecimal(trade.Price), binance.FormatDecimal(trade.Qty), binance.FormatDecimal(trade.QuoteQty),
+		trade.Time, trade.IsBuyerMaker, trade.IsBestMatch)
+	require.Equal(t, raw, formatted)
+
+	// json.Marshal keeps values but not trailing zeros
+	b, err := json.Marshal(trade)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"price":"0.1"`)
+	decoded := &binance.Trade{}
+	require.NoError(t, json.Unmarshal(b, decoded))
+	require.True(t, trade.Price.Equal(decoded.Price))
+	require.True(t, trade.Qty.Equal(decoded.Qty))
+	require.True(t, trade.QuoteQty.Equal(decoded.QuoteQty))
+}
+
+func BenchmarkParseDecimal(b *testing.B) {
+	in := []byte("60000.01000000")
+	b.Run("ParseDecimal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = binance.ParseDecimal(in)
+		}
+	})
+	b.Run("NewFromString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = decimal.NewFromString(string(in))
+		}
+	})
+}
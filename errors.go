@@ -6,24 +6,67 @@ import (
 )
 
 var (
-	ErrNilRequest      = errors.New("request is nil")
-	ErrEmptySymbol     = errors.New("symbol are missing")
-	ErrEmptyOrderID    = errors.New("order id must be set")
-	ErrEmptyLimit      = errors.New("empty price or quantity")
-	ErrMinStrategyType = errors.New("minimal strategy type can't be lower than 1000000")
-	ErrEmptyMarket     = errors.New("quantity or quote quantity expected")
-	ErrNilUnmarshal    = errors.New("UnmarshalJSON on nil pointer")
-	ErrInvalidJSON     = errors.New("invalid json")
+	ErrNilRequest       = errors.New("request is nil")
+	ErrEmptySymbol      = errors.New("symbol are missing")
+	ErrEmptyOrderID     = errors.New("order id must be set")
+	ErrEmptyLimit       = errors.New("empty price or quantity")
+	ErrEmptyQuantity    = errors.New("empty quantity")
+	ErrMinStrategyType  = errors.New("minimal strategy type can't be lower than 1000000")
+	ErrEmptyMarket      = errors.New("quantity or quote quantity expected")
+	ErrNilUnmarshal     = errors.New("UnmarshalJSON on nil pointer")
+	ErrInvalidJSON      = errors.New("invalid json")
+	ErrSymbolMismatch   = errors.New("order symbol doesn't match symbol info")
+	ErrInvalidTimeRange = errors.New("start time must be set and not after end time")
+	ErrTradeIDGap       = errors.New("trade ids are not consecutive")
+	ErrInvalidDecimal   = errors.New("invalid decimal")
+	ErrInvalidBinary    = errors.New("invalid binary data")
+
+	ErrEmptyOrderListID       = errors.New("order list id must be set")
+	ErrEmptyStopPrice         = errors.New("stop price or trailing delta expected")
+	ErrInvalidOrderListLegs   = errors.New("invalid order list leg types for the side")
+	ErrInvalidOrderType       = errors.New("order type isn't supported")
+	ErrInvalidOrderSide       = errors.New("order side must be BUY or SELL")
+	ErrForbiddenOrderParam    = errors.New("parameter isn't allowed")
+	ErrInvalidClientOrderID   = errors.New("invalid client order id")
+	ErrClientOrderIDExhausted = errors.New("client order id sequence exhausted")
+
+	ErrInvalidKlineInterval    = errors.New("invalid kline interval")
+	ErrInvalidResampleInterval = errors.New("resample interval must be a positive number of seconds")
+)
+
+// Binance API error codes
+const (
+	ErrCodeTooManyRequests    = -1003
+	ErrCodeUnexpectedResponse = -1006 // ErrCodeUnexpectedResponse means the order status is unknown
+	ErrCodeTimeout            = -1007 // ErrCodeTimeout means the order status is unknown
+	ErrCodeFilterFailure      = -1013
+	ErrCodeNewOrderReject     = -2010
+	ErrCodeNoSuchOrder        = -2013
+
+	ErrCodeCancelReplacePartiallyFailed = -2021
+	ErrCodeCancelReplaceFailed          = -2022
 )
 
 type APIError struct {
-	Code int    `json:"code"`
-	Msg  string `json:"msg"`
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data,omitempty"` // Data is the error payload, set only by some endpoints
 }
 
 // Error return error code and message
 func (e *APIError) Error() string {
-	bb, _ := json.Marshal(e)
+	bb, _ := json.Marshal(&APIError{Code: e.Code, Msg: e.Msg})
 
 	return b2s(bb)
 }
+
+// CancelReplaceError is returned when the cancel-replace failed at least partially,
+// Result tells which of the cancel and the new order succeeded
+type CancelReplaceError struct {
+	*APIError
+	Result *CancelReplaceOrder
+}
+
+func (e *CancelReplaceError) Unwrap() error {
+	return e.APIError
+}
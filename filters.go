@@ -0,0 +1,274 @@
+package binance
+
+import (
+	"github.com/xenking/decimal"
+)
+
+// PriceFilter defines the price rules for a symbol
+type PriceFilter struct {
+	MinPrice decimal.Decimal
+	MaxPrice decimal.Decimal
+	TickSize decimal.Decimal
+}
+
+// PercentPriceFilter defines the valid range for the price based on the average of the previous trades
+type PercentPriceFilter struct {
+	MultiplierUp   decimal.Decimal
+	MultiplierDown decimal.Decimal
+	AvgPriceMins   int
+}
+
+// PercentPriceBySideFilter defines the valid range for the price based on the average of the previous trades
+// with separate multipliers for each order side
+type PercentPriceBySideFilter struct {
+	BidMultiplierUp   decimal.Decimal
+	BidMultiplierDown decimal.Decimal
+	AskMultiplierUp   decimal.Decimal
+	AskMultiplierDown decimal.Decimal
+	AvgPriceMins      int
+}
+
+// LotSizeFilter defines the quantity rules for a symbol, used by both LOT_SIZE and MARKET_LOT_SIZE
+type LotSizeFilter struct {
+	MinQty   decimal.Decimal
+	MaxQty   decimal.Decimal
+	StepSize decimal.Decimal
+}
+
+// MinNotionalFilter defines the minimum notional value allowed for an order on a symbol
+type MinNotionalFilter struct {
+	MinNotional   decimal.Decimal
+	ApplyToMarket bool
+	AvgPriceMins  int
+}
+
+// NotionalFilter defines the acceptable notional range allowed for an order on a symbol
+type NotionalFilter struct {
+	MinNotional      decimal.Decimal
+	ApplyMinToMarket bool
+	MaxNotional      decimal.Decimal
+	ApplyMaxToMarket bool
+	AvgPriceMins     int
+}
+
+// TrailingDeltaFilter defines the minimum and maximum value for the trailingDelta parameter in BIPS
+type TrailingDeltaFilter struct {
+	MinTrailingAboveDelta int
+	MaxTrailingAboveDelta int
+	MinTrailingBelowDelta int
+	MaxTrailingBelowDelta int
+}
+
+// Filter returns the symbol filter of the given type or nil if the symbol doesn't have it
+func (s *SymbolInfo) Filter(t FilterType) *SymbolInfoFilter {
+	for i := range s.Filters {
+		if s.Filters[i].Type == t {
+			return &s.Filters[i]
+		}
+	}
+
+	return nil
+}
+
+// PriceFilter returns PRICE_FILTER parameters or nil if the filter isn't set
+func (s *SymbolInfo) PriceFilter() *PriceFilter {
+	f := s.Filter(FilterTypePrice)
+	if f == nil {
+		return nil
+	}
+
+	return &PriceFilter{
+		MinPrice: f.MinPrice,
+		MaxPrice: f.MaxPrice,
+		TickSize: f.TickSize,
+	}
+}
+
+// PercentPrice returns PERCENT_PRICE parameters or nil if the filter isn't set
+func (s *SymbolInfo) PercentPrice() *PercentPriceFilter {
+	f := s.Filter(FilterTypePercentPrice)
+	if f == nil {
+		return nil
+	}
+
+	return &PercentPriceFilter{
+		MultiplierUp:   f.MultiplierUp,
+		MultiplierDown: f.MultiplierDown,
+		AvgPriceMins:   f.AvgPriceMins,
+	}
+}
+
+// PercentPriceBySide returns PERCENT_PRICE_BY_SIDE parameters or nil if the filter isn't set
+func (s *SymbolInfo) PercentPriceBySide() *PercentPriceBySideFilter {
+	f := s.Filter(FilterTypePercentPriceBySide)
+	if f == nil {
+		return nil
+	}
+
+	return &PercentPriceBySideFilter{
+		BidMultiplierUp:   f.BidMultiplierUp,
+		BidMultiplierDown: f.BidMultiplierDown,
+		AskMultiplierUp:   f.AskMultiplierUp,
+		AskMultiplierDown: f.AskMultiplierDown,
+		AvgPriceMins:      f.AvgPriceMins,
+	}
+}
+
+// LotSize returns LOT_SIZE parameters or nil if the filter isn't set
+func (s *SymbolInfo) LotSize() *LotSizeFilter {
+	return s.lotSize(FilterTypeLotSize)
+}
+
+// MarketLotSize returns MARKET_LOT_SIZE parameters or nil if the filter isn't set
+func (s *SymbolInfo) MarketLotSize() *LotSizeFilter {
+	return s.lotSize(FilterTypeMarketLotSize)
+}
+
+func (s *SymbolInfo) lotSize(t FilterType) *LotSizeFilter {
+	f := s.Filter(t)
+	if f == nil {
+		return nil
+	}
+
+	return &LotSizeFilter{
+		MinQty:   f.MinQty,
+		MaxQty:   f.MaxQty,
+		StepSize: f.StepSize,
+	}
+}
+
+// MinNotional returns MIN_NOTIONAL parameters or nil if the filter isn't set
+func (s *SymbolInfo) MinNotional() *MinNotionalFilter {
+	f := s.Filter(FilterTypeMinNotional)
+	if f == nil {
+		return nil
+	}
+
+	return &MinNotionalFilter{
+		MinNotional:   f.MinNotional,
+		ApplyToMarket: f.ApplyToMarket,
+		AvgPriceMins:  f.AvgPriceMins,
+	}
+}
+
+// Notional returns NOTIONAL parameters or nil if the filter isn't set
+func (s *SymbolInfo) Notional() *NotionalFilter {
+	f := s.Filter(FilterTypeNotional)
+	if f == nil {
+		return nil
+	}
+
+	return &NotionalFilter{
+		MinNotional:      f.MinNotional,
+		ApplyMinToMarket: f.ApplyMinToMarket,
+		MaxNotional:      f.MaxNotional,
+		ApplyMaxToMarket: f.ApplyMaxToMarket,
+		AvgPriceMins:     f.AvgPriceMins,
+	}
+}
+
+// TrailingDelta returns TRAILING_DELTA parameters or nil if the filter isn't set
+func (s *SymbolInfo) TrailingDelta() *TrailingDeltaFilter {
+	f := s.Filter(FilterTypeTrailingDelta)
+	if f == nil {
+		return nil
+	}
+
+	return &TrailingDeltaFilter{
+		MinTrailingAboveDelta: f.MinTrailingAboveDelta,
+		MaxTrailingAboveDelta: f.MaxTrailingAboveDelta,
+		MinTrailingBelowDelta: f.MinTrailingBelowDelta,
+		MaxTrailingBelowDelta: f.MaxTrailingBelowDelta,
+	}
+}
+
+// IcebergParts returns the maximum parts an iceberg order can have
+func (s *SymbolInfo) IcebergParts() (int, bool) {
+	f := s.Filter(FilterTypeIcebergParts)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.IcebergLimit, true
+}
+
+// MaxNumOrders returns the maximum number of orders an account is allowed to have open on a symbol
+func (s *SymbolInfo) MaxNumOrders() (int, bool) {
+	f := s.Filter(FilterTypeMaxNumOrders)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.MaxNumOrders, true
+}
+
+// MaxNumAlgoOrders returns the maximum number of algo orders an account is allowed to have open on a symbol
+func (s *SymbolInfo) MaxNumAlgoOrders() (int, bool) {
+	f := s.Filter(FilterTypeMaxNumAlgoOrders)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.MaxNumAlgoOrders, true
+}
+
+// MaxNumIcebergOrders returns the maximum number of iceberg orders an account is allowed to have open on a symbol
+func (s *SymbolInfo) MaxNumIcebergOrders() (int, bool) {
+	f := s.Filter(FilterTypeMaxNumIcebergOrders)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.MaxNumIcebergOrders, true
+}
+
+// MaxNumOrderLists returns the maximum number of order lists an account is allowed to have open on a symbol
+func (s *SymbolInfo) MaxNumOrderLists() (int, bool) {
+	f := s.Filter(FilterTypeMaxNumOrderLists)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.MaxNumOrderLists, true
+}
+
+// MaxNumOrderAmends returns the maximum number of amendments a single order is allowed to have
+func (s *SymbolInfo) MaxNumOrderAmends() (int, bool) {
+	f := s.Filter(FilterTypeMaxNumOrderAmends)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.MaxNumOrderAmends, true
+}
+
+// MaxPosition returns the maximum position an account is allowed to have on the base asset of a symbol
+func (s *SymbolInfo) MaxPosition() (decimal.Decimal, bool) {
+	f := s.Filter(FilterTypeMaxPosition)
+	if f == nil {
+		return decimal.Zero, false
+	}
+
+	return f.MaxPosition, true
+}
+
+// TPlusSellEndTime returns the time in ms until which the T_PLUS_SELL restriction is active
+func (s *SymbolInfo) TPlusSellEndTime() (uint64, bool) {
+	f := s.Filter(FilterTypeTPlusSell)
+	if f == nil {
+		return 0, false
+	}
+
+	return f.EndTime, true
+}
+
+// Filter returns the exchange filter of the given type or nil if the exchange doesn't have it
+func (e *ExchangeInfo) Filter(t ExchangeFilterType) *ExchangeFilter {
+	for i := range e.ExchangeFilters {
+		if e.ExchangeFilters[i].Type == t {
+			return &e.ExchangeFilters[i]
+		}
+	}
+
+	return nil
+}
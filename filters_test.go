@@ -0,0 +1,106 @@
+package binance_test
+
+import (
+	"os"
+
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) mockFixture(endpoint, name string) {
+	fixture, err := os.ReadFile("testdata/" + name)
+	s.Require().NoError(err)
+
+	s.mock.Response = func(method, ep string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(endpoint, ep)
+		return fixture, nil
+	}
+}
+
+func (s *mockedTestSuite) TestExchangeInfoFilters() {
+	s.mockFixture(binance.EndpointExchangeInfo, "exchange_info.json")
+
+	info, err := s.api.ExchangeInfo()
+	s.Require().NoError(err)
+	s.Require().Len(info.Symbols, 2)
+	s.Require().Len(info.ExchangeFilters, 4)
+
+	s.Require().Equal(200, info.Filter(binance.ExchangeFilterTypeMaxNumAlgoOrders).MaxNumAlgoOrders)
+	s.Require().Equal(10000, info.Filter(binance.ExchangeFilterTypeMaxNumIcebergOrders).MaxNumIcebergOrders)
+	s.Require().Equal(20, info.Filter(binance.ExchangeFilterTypeMaxNumOrderLists).MaxNumOrderLists)
+
+	btc := info.Symbols[0]
+	s.Require().True(btc.OTOAllowed)
+	s.Require().Empty(btc.Permissions)
+	s.Require().Equal([][]binance.AccountType{{binance.AccountTypeSpot, binance.AccountTypeMargin}}, btc.PermissionSets)
+	s.Require().False(info.Symbols[1].OTOAllowed)
+	s.Require().Equal([]binance.AccountType{binance.AccountTypeSpot}, info.Symbols[1].Permissions)
+	s.Require().Empty(info.Symbols[1].PermissionSets)
+
+	price := btc.PriceFilter()
+	s.Require().NotNil(price)
+	s.Require().True(price.TickSize.Equal(decimal.RequireFromString("0.01")))
+	s.Require().True(price.MaxPrice.Equal(decimal.NewFromInt(1000000)))
+
+	lot := btc.LotSize()
+	s.Require().NotNil(lot)
+	s.Require().True(lot.StepSize.Equal(decimal.RequireFromString("0.00001")))
+	s.Require().True(btc.MarketLotSize().MaxQty.Equal(decimal.RequireFromString("115.42668111")))
+
+	notional := btc.Notional()
+	s.Require().NotNil(notional)
+	s.Require().True(notional.MinNotional.Equal(decimal.NewFromInt(5)))
+	s.Require().True(notional.MaxNotional.Equal(decimal.NewFromInt(9000000)))
+	s.Require().True(notional.ApplyMinToMarket)
+	s.Require().False(notional.ApplyMaxToMarket)
+	s.Require().Equal(5, notional.AvgPriceMins)
+	s.Require().Nil(btc.MinNotional())
+
+	bySide := btc.PercentPriceBySide()
+	s.Require().NotNil(bySide)
+	s.Require().True(bySide.BidMultiplierDown.Equal(decimal.RequireFromString("0.2")))
+	s.Require().True(bySide.AskMultiplierUp.Equal(decimal.NewFromInt(5)))
+	s.Require().Nil(btc.PercentPrice())
+
+	delta := btc.TrailingDelta()
+	s.Require().NotNil(delta)
+	s.Require().Equal(10, delta.MinTrailingAboveDelta)
+	s.Require().Equal(2000, delta.MaxTrailingBelowDelta)
+
+	parts, ok := btc.IcebergParts()
+	s.Require().True(ok)
+	s.Require().Equal(10, parts)
+	lists, ok := btc.MaxNumOrderLists()
+	s.Require().True(ok)
+	s.Require().Equal(20, lists)
+	amends, ok := btc.MaxNumOrderAmends()
+	s.Require().True(ok)
+	s.Require().Equal(10, amends)
+	_, ok = btc.MaxPosition()
+	s.Require().False(ok)
+
+	ltc := info.Symbols[1]
+	s.Require().Equal(binance.SymbolStatusBreak, ltc.Status)
+	percent := ltc.PercentPrice()
+	s.Require().NotNil(percent)
+	s.Require().True(percent.MultiplierUp.Equal(decimal.NewFromInt(5)))
+	s.Require().Equal(5, percent.AvgPriceMins)
+
+	minNotional := ltc.MinNotional()
+	s.Require().NotNil(minNotional)
+	s.Require().True(minNotional.MinNotional.Equal(decimal.RequireFromString("0.0001")))
+	s.Require().True(minNotional.ApplyToMarket)
+	s.Require().Nil(ltc.Notional())
+	s.Require().Nil(ltc.TrailingDelta())
+
+	icebergs, ok := ltc.MaxNumIcebergOrders()
+	s.Require().True(ok)
+	s.Require().Equal(5, icebergs)
+	position, ok := ltc.MaxPosition()
+	s.Require().True(ok)
+	s.Require().True(position.Equal(decimal.NewFromInt(10000)))
+	endTime, ok := ltc.TPlusSellEndTime()
+	s.Require().True(ok)
+	s.Require().Equal(uint64(1735689600000), endTime)
+}
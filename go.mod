@@ -8,7 +8,6 @@ require (
 	github.com/segmentio/encoding v0.3.5
 	github.com/stretchr/testify v1.7.0
 	github.com/valyala/fasthttp v1.40.0
-	github.com/xenking/binance-api v1.9.1
 	github.com/xenking/bytebufferpool v1.1.0
 	github.com/xenking/decimal v1.3.5
 	github.com/xenking/http2 v0.2.0
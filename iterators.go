@@ -0,0 +1,613 @@
+package binance
+
+import (
+	"time"
+)
+
+// KlinesIterator walks klines of a time range page by page.
+//
+//	it := client.KlinesRange(&binance.KlinesReq{Symbol: "BTCUSDT", Interval: binance.KlineInterval1min, StartTime: start})
+//	for it.Next() {
+//		k := it.Klines()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type KlinesIterator struct {
+	Pacer *Pacer
+
+	client *Client
+	req    KlinesReq
+	end    uint64
+
+	page     []*Klines
+	pos      int
+	cur      *Klines
+	lastOpen uint64
+	started  bool
+	done     bool
+	err      error
+}
+
+// KlinesRange returns iterator over klines from req.StartTime to req.EndTime inclusive.
+// EndTime zero means until the latest kline. Limit is used as a page size and defaults to MaxKlinesLimit.
+// Pages are requested by the close time of the last received kline, boundary duplicates are skipped.
+func (c *Client) KlinesRange(req *KlinesReq) *KlinesIterator {
+	it := &KlinesIterator{
+		Pacer:  NewPacer(),
+		client: c,
+	}
+	switch {
+	case req == nil:
+		it.err = ErrNilRequest
+	case req.Symbol == "":
+		it.err = ErrEmptySymbol
+	case req.StartTime == 0 || (req.EndTime != 0 && req.EndTime < req.StartTime):
+		it.err = ErrInvalidTimeRange
+	default:
+		it.req = *req
+		it.end = req.EndTime
+		if it.req.Limit <= 0 || it.req.Limit > MaxKlinesLimit {
+			it.req.Limit = MaxKlinesLimit
+		}
+	}
+	if it.err != nil {
+		it.done = true
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next kline, it returns false when the range is over or on error
+func (it *KlinesIterator) Next() bool {
+	for it.pos >= len(it.page) {
+		if it.done || !it.fetch() {
+			it.cur = nil
+
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+
+	return true
+}
+
+// Klines returns the current kline
+func (it *KlinesIterator) Klines() *Klines {
+	return it.cur
+}
+
+// Err returns the first error occurred during iteration
+func (it *KlinesIterator) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns all klines
+func (it *KlinesIterator) All() ([]*Klines, error) {
+	var res []*Klines
+	for it.Next() {
+		res = append(res, it.Klines())
+	}
+
+	return res, it.Err()
+}
+
+func (it *KlinesIterator) fetch() bool {
+	req := it.req
+	var klines []*Klines
+	err := it.Pacer.Do(it.client.RestClient, func() (err error) {
+		klines, err = it.client.Klines(&req)
+
+		return err
+	})
+	if err != nil {
+		it.err = err
+		it.done = true
+
+		return false
+	}
+	if len(klines) < req.Limit {
+		it.done = true
+	}
+
+	it.page = it.page[:0]
+	it.pos = 0
+	for _, k := range klines {
+		if it.end != 0 && k.OpenTime > it.end {
+			it.done = true
+
+			break
+		}
+		if k.CloseTime < req.StartTime || (it.started && k.OpenTime <= it.lastOpen) {
+			continue
+		}
+		it.page = append(it.page, k)
+		it.lastOpen = k.OpenTime
+		it.started = true
+	}
+	if len(klines) > 0 {
+		last := klines[len(klines)-1]
+		it.req.StartTime = last.CloseTime + 1
+		if it.end != 0 && it.req.StartTime > it.end {
+			it.done = true
+		}
+	} else {
+		it.done = true
+	}
+
+	return len(it.page) > 0 || !it.done
+}
+
+// AggTradesWindow is the time window in ms used to locate the first aggregate trade of a range.
+// It's kept under an hour to satisfy the startTime/endTime distance limit of the endpoint
+const AggTradesWindow = 60*60*1000 - 1
+
+// AggTradesIterator walks aggregate trades of a time range.
+// The first trade is located by startTime/endTime windows, then trades are requested by ID,
+// so the result has no gaps regardless of the range length.
+type AggTradesIterator struct {
+	Pacer *Pacer
+
+	client  *Client
+	req     AggregatedTradeReq
+	start   uint64
+	end     uint64
+	nextID  int64
+	started bool
+
+	page []*AggregatedTrade
+	pos  int
+	cur  *AggregatedTrade
+	done bool
+	err  error
+}
+
+// AggregatedTradesRange returns iterator over aggregate trades from req.StartTime to req.EndTime inclusive.
+// EndTime zero means until now. Limit is used as a page size and defaults to MaxTradesLimit.
+// If req.FromID is set the iteration starts from this ID and StartTime is ignored.
+func (c *Client) AggregatedTradesRange(req *AggregatedTradeReq) *AggTradesIterator {
+	it := &AggTradesIterator{
+		Pacer:  NewPacer(),
+		client: c,
+	}
+	switch {
+	case req == nil:
+		it.err = ErrNilRequest
+	case req.Symbol == "":
+		it.err = ErrEmptySymbol
+	case req.FromID == nil && (req.StartTime == 0 || (req.EndTime != 0 && req.EndTime < req.StartTime)):
+		it.err = ErrInvalidTimeRange
+	default:
+		it.req = AggregatedTradeReq{Symbol: req.Symbol, Limit: req.Limit}
+		it.start = req.StartTime
+		it.end = req.EndTime
+		if req.FromID != nil {
+			it.nextID = *req.FromID
+			it.started = true
+		}
+		if it.end == 0 {
+			it.end = uint64(time.Now().UnixMilli())
+		}
+		if it.req.Limit <= 0 || it.req.Limit > MaxTradesLimit {
+			it.req.Limit = MaxTradesLimit
+		}
+	}
+	if it.err != nil {
+		it.done = true
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next trade, it returns false when the range is over or on error
+func (it *AggTradesIterator) Next() bool {
+	for it.pos >= len(it.page) {
+		if it.done || !it.fetch() {
+			it.cur = nil
+
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+
+	return true
+}
+
+// Trade returns the current trade
+func (it *AggTradesIterator) Trade() *AggregatedTrade {
+	return it.cur
+}
+
+// Err returns the first error occurred during iteration
+func (it *AggTradesIterator) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns all trades
+func (it *AggTradesIterator) All() ([]*AggregatedTrade, error) {
+	var res []*AggregatedTrade
+	for it.Next() {
+		res = append(res, it.Trade())
+	}
+
+	return res, it.Err()
+}
+
+func (it *AggTradesIterator) fetch() bool {
+	req := it.req
+	locating := !it.started
+	if locating {
+		req.StartTime = it.start
+		req.EndTime = it.start + AggTradesWindow
+		if req.EndTime > it.end {
+			req.EndTime = it.end
+		}
+	} else {
+		req.WithFromID(it.nextID)
+	}
+
+	var trades []*AggregatedTrade
+	err := it.Pacer.Do(it.client.RestClient, func() (err error) {
+		trades, err = it.client.AggregatedTrades(&req)
+
+		return err
+	})
+	if err != nil {
+		it.err = err
+		it.done = true
+
+		return false
+	}
+
+	it.page = it.page[:0]
+	it.pos = 0
+	if len(trades) == 0 {
+		if locating {
+			// move to the next window until the end of the range
+			it.start = req.EndTime + 1
+			it.done = it.start > it.end
+		} else {
+			it.done = true
+		}
+
+		return !it.done
+	}
+	for _, t := range trades {
+		if t.Time > it.end {
+			it.done = true
+
+			break
+		}
+		if it.started && t.TradeID != it.nextID {
+			it.err = ErrTradeIDGap
+			it.done = true
+
+			return false
+		}
+		it.page = append(it.page, t)
+		it.nextID = t.TradeID + 1
+		it.started = true
+	}
+	if !locating && len(trades) < req.Limit {
+		it.done = true
+	}
+	if locating && !it.started {
+		it.done = true
+	}
+
+	return len(it.page) > 0 || !it.done
+}
+
+// TradesIterator walks historical trades by ID
+type TradesIterator struct {
+	Pacer *Pacer
+
+	client   *Client
+	req      HistoricalTradeReq
+	backward bool
+	nextID   int64
+	started  bool
+
+	page []*Trade
+	pos  int
+	cur  *Trade
+	done bool
+	err  error
+}
+
+// HistoricalTradesRange returns iterator over historical trades starting from req.FromID inclusive,
+// nil FromID starts from the most recent trades. Trades are returned in ascending order until the most recent trade,
+// or if backward is set, in descending order until the trade with ID 0.
+// Limit is used as a page size and defaults to MaxTradesLimit.
+func (c *Client) HistoricalTradesRange(req *HistoricalTradeReq, backward bool) *TradesIterator {
+	it := &TradesIterator{
+		Pacer:    NewPacer(),
+		client:   c,
+		backward: backward,
+	}
+	switch {
+	case req == nil:
+		it.err = ErrNilRequest
+	case req.Symbol == "":
+		it.err = ErrEmptySymbol
+	default:
+		it.req = *req
+		if req.FromID != nil {
+			it.nextID = *req.FromID
+			it.started = true
+		}
+		if it.req.Limit <= 0 || it.req.Limit > MaxTradesLimit {
+			it.req.Limit = MaxTradesLimit
+		}
+	}
+	if it.err != nil {
+		it.done = true
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next trade, it returns false when trades are over or on error
+func (it *TradesIterator) Next() bool {
+	for it.pos >= len(it.page) {
+		if it.done || !it.fetch() {
+			it.cur = nil
+
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+
+	return true
+}
+
+// Trade returns the current trade
+func (it *TradesIterator) Trade() *Trade {
+	return it.cur
+}
+
+// Err returns the first error occurred during iteration
+func (it *TradesIterator) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns all trades
+func (it *TradesIterator) All() ([]*Trade, error) {
+	var res []*Trade
+	for it.Next() {
+		res = append(res, it.Trade())
+	}
+
+	return res, it.Err()
+}
+
+func (it *TradesIterator) fetch() bool {
+	req := it.req
+	if it.started {
+		from := it.nextID
+		if it.backward {
+			from = it.nextID - int64(req.Limit) + 1
+			if from < 0 {
+				from = 0
+			}
+			req.Limit = int(it.nextID - from + 1)
+		}
+		req.WithFromID(from)
+	}
+
+	var trades []*Trade
+	err := it.Pacer.Do(it.client.RestClient, func() (err error) {
+		trades, err = it.client.HistoricalTrades(&req)
+
+		return err
+	})
+	if err != nil {
+		it.err = err
+		it.done = true
+
+		return false
+	}
+
+	it.page = it.page[:0]
+	it.pos = 0
+	if !it.started && len(trades) > 0 {
+		// the most recent trades, continue from the edge of the page
+		it.nextID = trades[0].ID
+		if it.backward {
+			it.nextID = trades[len(trades)-1].ID
+		}
+		it.started = true
+	}
+	if it.backward {
+		// trades are returned in ascending order, drop those after the cursor and reverse
+		for i := len(trades) - 1; i >= 0; i-- {
+			t := trades[i]
+			if t.ID > it.nextID {
+				continue
+			}
+			if t.ID != it.nextID {
+				it.err = ErrTradeIDGap
+				it.done = true
+
+				return false
+			}
+			it.page = append(it.page, t)
+			it.nextID--
+		}
+		it.done = it.nextID < 0 || len(it.page) == 0
+	} else {
+		for _, t := range trades {
+			if t.ID != it.nextID {
+				it.err = ErrTradeIDGap
+				it.done = true
+
+				return false
+			}
+			it.page = append(it.page, t)
+			it.nextID++
+		}
+		it.done = len(trades) < req.Limit
+	}
+
+	return len(it.page) > 0
+}
+
+// AccountTradesWindow is the time window in ms used to locate the first account trade of a range.
+// It's kept under 24 hours to satisfy the startTime/endTime distance limit of the endpoint
+const AccountTradesWindow = 24*60*60*1000 - 1
+
+// AccountTradesIterator walks account trades of a symbol in a time range.
+// The first trade is located by startTime/endTime windows, then trades are requested by ID
+// which are increasing but not consecutive, since other accounts trades are skipped.
+//
+// Remark: every request has weight 20 and windows are up to 24 hours long, so locating the first trade
+// of a long range without trades costs a request per day. Set FromID when the first trade ID is known.
+type AccountTradesIterator struct {
+	Pacer *Pacer
+
+	client   *Client
+	req      AccountTradesReq
+	start    uint64
+	end      uint64
+	nextID   int64
+	locating bool // locating is set until the first trade is found by time windows
+
+	page []*AccountTrades
+	pos  int
+	cur  *AccountTrades
+	done bool
+	err  error
+}
+
+// AccountTradesRange returns iterator over account trades from req.StartTime to req.EndTime inclusive.
+// EndTime zero means until now. Limit is used as a page size and defaults to MaxAccountTradesLimit.
+// If req.FromID is set the iteration starts from this ID and StartTime is ignored.
+func (c *Client) AccountTradesRange(req *AccountTradesReq) *AccountTradesIterator {
+	it := &AccountTradesIterator{
+		Pacer:  NewPacer(),
+		client: c,
+	}
+	switch {
+	case req == nil:
+		it.err = ErrNilRequest
+	case req.Symbol == "":
+		it.err = ErrEmptySymbol
+	case req.FromID == nil && (req.StartTime == 0 || (req.EndTime != 0 && req.EndTime < req.StartTime)):
+		it.err = ErrInvalidTimeRange
+	default:
+		it.req = AccountTradesReq{Symbol: req.Symbol, Limit: req.Limit}
+		it.start = req.StartTime
+		it.end = req.EndTime
+		it.locating = req.FromID == nil
+		if !it.locating {
+			it.nextID = *req.FromID
+		}
+		if it.end == 0 {
+			it.end = uint64(time.Now().UnixMilli())
+		}
+		if it.req.Limit <= 0 || it.req.Limit > MaxAccountTradesLimit {
+			it.req.Limit = MaxAccountTradesLimit
+		}
+	}
+	if it.err != nil {
+		it.done = true
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next trade, it returns false when the range is over or on error
+func (it *AccountTradesIterator) Next() bool {
+	for it.pos >= len(it.page) {
+		if it.done || !it.fetch() {
+			it.cur = nil
+
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+
+	return true
+}
+
+// Trade returns the current trade
+func (it *AccountTradesIterator) Trade() *AccountTrades {
+	return it.cur
+}
+
+// Err returns the first error occurred during iteration
+func (it *AccountTradesIterator) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns all trades
+func (it *AccountTradesIterator) All() ([]*AccountTrades, error) {
+	var res []*AccountTrades
+	for it.Next() {
+		res = append(res, it.Trade())
+	}
+
+	return res, it.Err()
+}
+
+func (it *AccountTradesIterator) fetch() bool {
+	req := it.req
+	locating := it.locating
+	if locating {
+		req.StartTime = it.start
+		req.EndTime = it.start + AccountTradesWindow
+		if req.EndTime > it.end {
+			req.EndTime = it.end
+		}
+	} else {
+		req.WithFromID(it.nextID)
+	}
+
+	var trades []*AccountTrades
+	err := it.Pacer.Do(it.client.RestClient, func() (err error) {
+		trades, err = it.client.AccountTrades(&req)
+
+		return err
+	})
+	if err != nil {
+		it.err = err
+		it.done = true
+
+		return false
+	}
+
+	it.page = it.page[:0]
+	it.pos = 0
+	if len(trades) == 0 {
+		if locating {
+			// move to the next window until the end of the range
+			it.start = req.EndTime + 1
+			it.done = it.start > it.end
+		} else {
+			it.done = true
+		}
+
+		return !it.done
+	}
+	for _, t := range trades {
+		if t.Time > it.end {
+			it.done = true
+
+			break
+		}
+		if t.ID < it.nextID {
+			continue
+		}
+		it.page = append(it.page, t)
+		it.nextID = t.ID + 1
+	}
+	it.locating = false
+	if !locating && len(trades) < req.Limit {
+		it.done = true
+	}
+
+	return len(it.page) > 0 || !it.done
+}
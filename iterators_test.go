@@ -0,0 +1,278 @@
+package binance_test
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ugi1/binance-api"
+)
+
+const minuteMs = 60000
+
+// mockKlines serves 1m klines in [first, last] open time range honoring startTime, endTime and limit.
+// Every page also repeats the kline preceding startTime to check boundary deduplication
+func (s *mockedTestSuite) mockKlines(first, last uint64, calls *int) {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointKlines, endpoint)
+		req := data.(*binance.KlinesReq)
+		*calls++
+
+		start := req.StartTime - req.StartTime%minuteMs
+		if start > first {
+			start -= minuteMs
+		}
+		if start < first {
+			start = first
+		}
+		end := last
+		if req.EndTime != 0 && req.EndTime < end {
+			end = req.EndTime
+		}
+		var rows []string
+		for t := start; t <= end && len(rows) < req.Limit; t += minuteMs {
+			rows = append(rows, klineRow(t))
+		}
+
+		return []byte("[" + strings.Join(rows, ",") + "]"), nil
+	}
+}
+
+func klineRow(openTime uint64) string {
+	open := strconv.FormatUint(openTime, 10)
+	closeTime := strconv.FormatUint(openTime+minuteMs-1, 10)
+
+	return `[` + open + `,"1.0","2.0","0.5","1.5","10",` + closeTime + `,"15",3,"5","7.5","0"]`
+}
+
+func (s *mockedTestSuite) TestKlinesRange() {
+	const first = 1700000000000 - 1700000000000%minuteMs
+	last := uint64(first + 2499*minuteMs)
+
+	calls := 0
+	s.mockKlines(first, last, &calls)
+
+	klines, err := s.api.KlinesRange(&binance.KlinesReq{
+		Symbol:    "BTCUSDT",
+		Interval:  binance.KlineInterval1min,
+		StartTime: first,
+	}).All()
+	s.Require().NoError(err)
+	s.Require().Len(klines, 2500)
+	s.Require().Equal(3, calls)
+	for i, k := range klines {
+		s.Require().Equal(uint64(first+i*minuteMs), k.OpenTime)
+	}
+
+	calls = 0
+	it := s.api.KlinesRange(&binance.KlinesReq{
+		Symbol:    "BTCUSDT",
+		Interval:  binance.KlineInterval1min,
+		StartTime: first + 10*minuteMs,
+		EndTime:   first + 1209*minuteMs,
+		Limit:     500,
+	})
+	n := 0
+	for it.Next() {
+		s.Require().Equal(uint64(first+(10+n)*minuteMs), it.Klines().OpenTime)
+		n++
+	}
+	s.Require().NoError(it.Err())
+	s.Require().Equal(1200, n)
+	s.Require().Equal(3, calls)
+
+	_, err = s.api.KlinesRange(&binance.KlinesReq{Symbol: "BTCUSDT", StartTime: 10, EndTime: 5}).All()
+	s.Require().ErrorIs(err, binance.ErrInvalidTimeRange)
+}
+
+// mockAggTrades serves aggregate trades with consecutive IDs starting at firstID, one trade per step ms from firstTime
+func (s *mockedTestSuite) mockAggTrades(firstID, count int, firstTime, step uint64, calls *int) {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointAggTrades, endpoint)
+		req := data.(*binance.AggregatedTradeReq)
+		*calls++
+
+		var rows []string
+		for i := 0; i < count && len(rows) < req.Limit; i++ {
+			id, t := firstID+i, firstTime+uint64(i)*step
+			if req.FromID != nil && int64(id) < *req.FromID {
+				continue
+			}
+			if req.StartTime != 0 && (t < req.StartTime || t > req.EndTime) {
+				continue
+			}
+			s.Require().Less(req.EndTime-req.StartTime, uint64(60*minuteMs))
+			rows = append(rows, `{"a":`+strconv.Itoa(id)+`,"p":"1","q":"1","f":1,"l":1,"T":`+strconv.FormatUint(t, 10)+`}`)
+		}
+
+		return []byte("[" + strings.Join(rows, ",") + "]"), nil
+	}
+}
+
+func (s *mockedTestSuite) TestAggregatedTradesRange() {
+	const start = 1700000000000
+	calls := 0
+	// the first trade is 3 hours after the range start
+	s.mockAggTrades(100, 3000, start+180*minuteMs, 1000, &calls)
+
+	trades, err := s.api.AggregatedTradesRange(&binance.AggregatedTradeReq{
+		Symbol:    "BTCUSDT",
+		StartTime: start,
+		EndTime:   start + 180*minuteMs + 2499*1000,
+	}).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 2500)
+	for i, t := range trades {
+		s.Require().Equal(int64(100+i), t.TradeID)
+	}
+	// 3 empty windows, the located window and 2 pages by ID
+	s.Require().Equal(6, calls)
+
+	calls = 0
+	s.mockAggTrades(100, 10, start, 1000, &calls)
+	trades, err = s.api.AggregatedTradesRange(&binance.AggregatedTradeReq{
+		Symbol:    "BTCUSDT",
+		StartTime: start + 5*60*minuteMs,
+		EndTime:   start + 10*60*minuteMs,
+	}).All()
+	s.Require().NoError(err)
+	s.Require().Empty(trades)
+	// 5 full windows and the last millisecond of the inclusive range
+	s.Require().Equal(6, calls)
+
+	// zero fromId is a valid aggregate trade ID
+	calls = 0
+	s.mockAggTrades(0, 10, start, 1000, &calls)
+	trades, err = s.api.AggregatedTradesRange((&binance.AggregatedTradeReq{Symbol: "BTCUSDT"}).WithFromID(0)).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 10)
+	s.Require().Equal(int64(0), trades[0].TradeID)
+	s.Require().Equal(1, calls)
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		return []byte(`[{"a":1,"T":1700000000000},{"a":3,"T":1700000000001}]`), nil
+	}
+	_, err = s.api.AggregatedTradesRange(&binance.AggregatedTradeReq{Symbol: "BTCUSDT", StartTime: start, EndTime: start + 1}).All()
+	s.Require().ErrorIs(err, binance.ErrTradeIDGap)
+
+	_, err = s.api.AggregatedTradesRange(&binance.AggregatedTradeReq{Symbol: "BTCUSDT"}).All()
+	s.Require().ErrorIs(err, binance.ErrInvalidTimeRange)
+}
+
+// mockHistoricalTrades serves trades with IDs from 0 to last
+func (s *mockedTestSuite) mockHistoricalTrades(last int, calls *int) {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointHistoricalTrades, endpoint)
+		req := data.(*binance.HistoricalTradeReq)
+		*calls++
+
+		from := last - req.Limit + 1
+		if req.FromID != nil {
+			from = int(*req.FromID)
+		}
+		var rows []string
+		for id := from; id <= last && len(rows) < req.Limit; id++ {
+			rows = append(rows, `{"id":`+strconv.Itoa(id)+`,"price":"1","qty":"1","time":`+strconv.Itoa(id)+`}`)
+		}
+
+		return []byte("[" + strings.Join(rows, ",") + "]"), nil
+	}
+}
+
+func (s *mockedTestSuite) TestHistoricalTradesRange() {
+	calls := 0
+	s.mockHistoricalTrades(2500, &calls)
+
+	trades, err := s.api.HistoricalTradesRange((&binance.HistoricalTradeReq{Symbol: "BTCUSDT"}).WithFromID(301), false).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 2200)
+	for i, t := range trades {
+		s.Require().Equal(int64(301+i), t.ID)
+	}
+	s.Require().Equal(3, calls)
+
+	calls = 0
+	trades, err = s.api.HistoricalTradesRange((&binance.HistoricalTradeReq{Symbol: "BTCUSDT", Limit: 500}).WithFromID(1200), true).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 1201)
+	for i, t := range trades {
+		s.Require().Equal(int64(1200-i), t.ID)
+	}
+	s.Require().Equal(3, calls)
+
+	calls = 0
+	trades, err = s.api.HistoricalTradesRange(&binance.HistoricalTradeReq{Symbol: "BTCUSDT"}, true).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 2501)
+	s.Require().Equal(int64(2500), trades[0].ID)
+	s.Require().Equal(int64(0), trades[2500].ID)
+	s.Require().Equal(3, calls)
+
+	// zero fromId is sent, it differs from the most recent trades
+	calls = 0
+	trades, err = s.api.HistoricalTradesRange((&binance.HistoricalTradeReq{Symbol: "BTCUSDT", Limit: 10}).WithFromID(0), false).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 2501)
+	s.Require().Equal(int64(0), trades[0].ID)
+}
+
+// mockAccountTrades serves account trades with every third ID starting at firstID, one trade per step ms from firstTime
+func (s *mockedTestSuite) mockAccountTrades(firstID, count int, firstTime, step uint64, calls *int) {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointAccountTrades, endpoint)
+		req := data.(*binance.AccountTradesReq)
+		*calls++
+
+		var rows []string
+		for i := 0; i < count && len(rows) < req.Limit; i++ {
+			id, t := firstID+3*i, firstTime+uint64(i)*step
+			if req.FromID != nil && int64(id) < *req.FromID {
+				continue
+			}
+			if req.FromID == nil && req.StartTime != 0 && (t < req.StartTime || t > req.EndTime) {
+				continue
+			}
+			s.Require().Less(req.EndTime-req.StartTime, uint64(24*60*minuteMs))
+			rows = append(rows, `{"symbol":"BTCUSDT","id":`+strconv.Itoa(id)+`,"price":"1","qty":"1","time":`+strconv.FormatUint(t, 10)+`}`)
+		}
+
+		return []byte("[" + strings.Join(rows, ",") + "]"), nil
+	}
+}
+
+func (s *mockedTestSuite) TestAccountTradesRange() {
+	const start = 1700000000000
+	calls := 0
+	// the first trade is 2 days after the range start
+	s.mockAccountTrades(100, 1200, start+2*24*60*minuteMs, 1000, &calls)
+
+	trades, err := s.api.AccountTradesRange(&binance.AccountTradesReq{
+		Symbol:    "BTCUSDT",
+		StartTime: start,
+		EndTime:   start + 2*24*60*minuteMs + 1099*1000,
+	}).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 1100)
+	for i, t := range trades {
+		s.Require().Equal(int64(100+3*i), t.ID)
+	}
+	// 2 empty windows, the located window and 2 pages by ID
+	s.Require().Equal(5, calls)
+
+	calls = 0
+	trades, err = s.api.AccountTradesRange((&binance.AccountTradesReq{Symbol: "BTCUSDT"}).WithFromID(3100)).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 200)
+	s.Require().Equal(1, calls)
+
+	// zero is a valid trade ID, it doesn't start the window search
+	calls = 0
+	s.mockAccountTrades(0, 10, start, 1000, &calls)
+	trades, err = s.api.AccountTradesRange((&binance.AccountTradesReq{Symbol: "BTCUSDT"}).WithFromID(0)).All()
+	s.Require().NoError(err)
+	s.Require().Len(trades, 10)
+	s.Require().Equal(int64(0), trades[0].ID)
+	s.Require().Equal(1, calls)
+
+	_, err = s.api.AccountTradesRange(&binance.AccountTradesReq{Symbol: "BTCUSDT"}).All()
+	s.Require().ErrorIs(err, binance.ErrInvalidTimeRange)
+}
@@ -0,0 +1,380 @@
+// Package klinestore keeps historical klines on disk and fetches only the ranges missing locally.
+//
+// Klines of every symbol and interval are stored in daily CSV files for intervals shorter than 1h
+// and in monthly ones otherwise, named and formatted like data.binance.vision files, so they can be read
+// by the archive package. A JSON file next to them keeps the open time ranges already fetched.
+// Ranges without klines, e.g. before the symbol listing, are remembered too. Only closed klines are stored.
+// Requests read only files of the requested range and fetched klines rewrite only files they belong to.
+package klinestore
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/archive"
+)
+
+// Range is an inclusive range of kline open times in ms
+type Range struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// Store is a kline cache in the given directory, it's safe for concurrent use within a process
+type Store struct {
+	// Pacer throttles requests of missing ranges, default pacer is used if it's nil
+	Pacer *binance.Pacer
+
+	dir    string
+	client *binance.Client
+
+	mu sync.Mutex
+}
+
+// New creates store in dir, the directory is created on the first write
+func New(dir string, client *binance.Client) *Store {
+	return &Store{
+		dir:    dir,
+		client: client,
+	}
+}
+
+// Klines returns closed klines with open time in [start, end], start is aligned to the interval boundary.
+// Ranges which aren't stored yet are fetched and saved before returning
+func (s *Store) Klines(symbol string, interval binance.KlineInterval, start, end time.Time) ([]*binance.Klines, error) {
+	switch {
+	case symbol == "":
+		return nil, binance.ErrEmptySymbol
+	case !interval.Valid():
+		return nil, binance.ErrInvalidKlineInterval
+	case start.IsZero() || end.Before(start):
+		return nil, binance.ErrInvalidTimeRange
+	}
+	from := binance.TimeToMs(interval.Truncate(start))
+	to := binance.TimeToMs(end)
+	// the current kline isn't closed yet
+	current := binance.TimeToMs(interval.Truncate(time.Now()))
+	if to >= current {
+		to = current - 1
+	}
+	if to < from {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ranges, err := s.loadRanges(symbol, interval)
+	if err != nil {
+		return nil, err
+	}
+	gaps := missing(ranges, from, to)
+	var fetched []*binance.Klines
+	for _, gap := range gaps {
+		it := s.client.KlinesRange(&binance.KlinesReq{
+			Symbol:    symbol,
+			Interval:  interval,
+			StartTime: gap.From,
+			EndTime:   gap.To,
+		})
+		if s.Pacer != nil {
+			it.Pacer = s.Pacer
+		}
+		klines, err := it.All()
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch %s %s klines", symbol, interval)
+		}
+		for _, k := range klines {
+			if k.OpenTime < current {
+				fetched = append(fetched, k)
+			}
+		}
+		ranges = addRange(ranges, gap)
+	}
+	if len(gaps) > 0 {
+		if err = os.MkdirAll(s.path(symbol, interval), 0o755); err != nil {
+			return nil, err
+		}
+		// klines are written before ranges, so interrupted save doesn't mark missing klines as stored
+		if err = s.saveKlines(symbol, interval, fetched); err != nil {
+			return nil, err
+		}
+		if err = s.saveRanges(symbol, interval, ranges); err != nil {
+			return nil, err
+		}
+	}
+
+	var res []*binance.Klines
+	last := segmentStart(interval, to)
+	for seg := segmentStart(interval, from); !seg.After(last); seg = nextSegment(interval, seg) {
+		klines, err := s.loadSegment(symbol, interval, seg)
+		if err != nil {
+			return nil, err
+		}
+		lo := sort.Search(len(klines), func(i int) bool { return klines[i].OpenTime >= from })
+		hi := sort.Search(len(klines), func(i int) bool { return klines[i].OpenTime > to })
+		res = append(res, klines[lo:hi]...)
+	}
+
+	return res, nil
+}
+
+// Ranges returns the stored open time ranges
+func (s *Store) Ranges(symbol string, interval binance.KlineInterval) ([]Range, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.loadRanges(symbol, interval)
+}
+
+func (s *Store) path(symbol string, interval binance.KlineInterval) string {
+	name := string(interval)
+	// 1m and 1M files would collide on case insensitive file systems
+	if interval == binance.KlineInterval1month {
+		name = "1mo"
+	}
+
+	return filepath.Join(s.dir, symbol, name)
+}
+
+// daily reports whether klines of the interval are split into daily files instead of monthly ones,
+// so files of short intervals stay small
+func daily(interval binance.KlineInterval) bool {
+	return interval.Duration() < time.Hour
+}
+
+// segmentStart returns the start of the day or month file containing the open time
+func segmentStart(interval binance.KlineInterval, ms uint64) time.Time {
+	t := time.UnixMilli(int64(ms)).UTC()
+	if daily(interval) {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func nextSegment(interval binance.KlineInterval, seg time.Time) time.Time {
+	if daily(interval) {
+		return seg.AddDate(0, 0, 1)
+	}
+
+	return seg.AddDate(0, 1, 0)
+}
+
+func (s *Store) segmentPath(symbol string, interval binance.KlineInterval, seg time.Time) string {
+	layout := "2006-01"
+	if daily(interval) {
+		layout = "2006-01-02"
+	}
+
+	return filepath.Join(s.path(symbol, interval), symbol+"-"+string(interval)+"-"+seg.Format(layout)+".csv")
+}
+
+func (s *Store) loadRanges(symbol string, interval binance.KlineInterval) ([]Range, error) {
+	raw, err := os.ReadFile(s.path(symbol, interval) + ".json")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ranges []Range
+	err = json.Unmarshal(raw, &ranges)
+
+	return ranges, err
+}
+
+func (s *Store) saveRanges(symbol string, interval binance.KlineInterval, ranges []Range) error {
+	return writeFile(s.path(symbol, interval)+".json", func(w *bufio.Writer) error {
+		raw, err := json.Marshal(ranges)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(raw)
+
+		return err
+	})
+}
+
+// loadSegment reads klines of the day or month file, a missing file has no klines
+func (s *Store) loadSegment(symbol string, interval binance.KlineInterval, seg time.Time) ([]*binance.Klines, error) {
+	r, err := archive.Open(s.segmentPath(symbol, interval, seg))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var klines []*binance.Klines
+	for {
+		k, err := r.ReadKlines()
+		if errors.Is(err, io.EOF) {
+			return klines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, k)
+	}
+}
+
+// saveKlines merges sorted fetched klines into their day or month files, only files receiving klines are rewritten
+func (s *Store) saveKlines(symbol string, interval binance.KlineInterval, fetched []*binance.Klines) error {
+	for len(fetched) > 0 {
+		seg := segmentStart(interval, fetched[0].OpenTime)
+		end := binance.TimeToMs(nextSegment(interval, seg))
+		n := sort.Search(len(fetched), func(i int) bool { return fetched[i].OpenTime >= end })
+		stored, err := s.loadSegment(symbol, interval, seg)
+		if err != nil {
+			return err
+		}
+		klines := mergeKlines(stored, fetched[:n])
+		err = writeFile(s.segmentPath(symbol, interval, seg), func(w *bufio.Writer) error {
+			var line []byte
+			for _, k := range klines {
+				line = appendKline(line[:0], k)
+				if _, err := w.Write(line); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fetched = fetched[n:]
+	}
+
+	return nil
+}
+
+// writeFile replaces the file atomically
+func writeFile(path string, write func(w *bufio.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	err = write(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+func appendKline(b []byte, k *binance.Klines) []byte {
+	b = strconv.AppendUint(b, k.OpenTime, 10)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.OpenPrice)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.High)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.Low)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.ClosePrice)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.Volume)
+	b = append(b, ',')
+	b = strconv.AppendUint(b, k.CloseTime, 10)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.QuoteAssetVolume)
+	b = append(b, ',')
+	b = strconv.AppendInt(b, int64(k.Trades), 10)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.TakerBuyBaseAssetVolume)
+	b = append(b, ',')
+	b = binance.AppendDecimal(b, k.TakerBuyQuoteAssetVolume)
+	b = append(b, ",0\n"...)
+
+	return b
+}
+
+// missing returns parts of [from, to] not covered by sorted ranges
+func missing(ranges []Range, from, to uint64) []Range {
+	var gaps []Range
+	for _, r := range ranges {
+		if r.To < from {
+			continue
+		}
+		if r.From > to {
+			break
+		}
+		if r.From > from {
+			gaps = append(gaps, Range{From: from, To: r.From - 1})
+		}
+		from = r.To + 1
+		if from > to || r.To == ^uint64(0) {
+			return gaps
+		}
+	}
+
+	return append(gaps, Range{From: from, To: to})
+}
+
+// addRange inserts r into sorted ranges merging overlapping and adjacent ones
+func addRange(ranges []Range, r Range) []Range {
+	ranges = append(ranges, r)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].From < ranges[j].From })
+	res := ranges[:1]
+	for _, next := range ranges[1:] {
+		last := &res[len(res)-1]
+		if next.From <= last.To+1 {
+			if next.To > last.To {
+				last.To = next.To
+			}
+
+			continue
+		}
+		res = append(res, next)
+	}
+
+	return res
+}
+
+// mergeKlines merges sorted klines, fetched klines replace stored ones with the same open time
+func mergeKlines(stored, fetched []*binance.Klines) []*binance.Klines {
+	if len(fetched) == 0 {
+		return stored
+	}
+	res := make([]*binance.Klines, 0, len(stored)+len(fetched))
+	i, j := 0, 0
+	for i < len(stored) && j < len(fetched) {
+		switch {
+		case stored[i].OpenTime < fetched[j].OpenTime:
+			res = append(res, stored[i])
+			i++
+		case stored[i].OpenTime > fetched[j].OpenTime:
+			res = append(res, fetched[j])
+			j++
+		default:
+			res = append(res, fetched[j])
+			i++
+			j++
+		}
+	}
+	res = append(res, stored[i:]...)
+
+	return append(res, fetched[j:]...)
+}
@@ -0,0 +1,118 @@
+package klinestore_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/klinestore"
+)
+
+const minuteMs = 60000
+
+// klinesClient serves 1m klines for any range and records requested ranges
+type klinesClient struct {
+	requests []binance.KlinesReq
+}
+
+func (c *klinesClient) Do(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+	req := *data.(*binance.KlinesReq)
+	c.requests = append(c.requests, req)
+
+	start := req.StartTime - req.StartTime%minuteMs
+	if start < req.StartTime {
+		start += minuteMs
+	}
+	var rows []string
+	for t := start; t <= req.EndTime && len(rows) < req.Limit; t += minuteMs {
+		open := strconv.FormatUint(t, 10)
+		closeTime := strconv.FormatUint(t+minuteMs-1, 10)
+		rows = append(rows, `[`+open+`,"1.00000000","2.00000000","0.50000000","1.50000000","10.00000000",`+closeTime+`,"15.00000000",3,"5.00000000","7.50000000","0"]`)
+	}
+
+	return []byte("[" + strings.Join(rows, ",") + "]"), nil
+}
+
+func (c *klinesClient) SetWindow(int)                {}
+func (c *klinesClient) UsedWeight() map[string]int64 { return nil }
+func (c *klinesClient) OrderCount() map[string]int64 { return nil }
+func (c *klinesClient) RetryAfter() int64            { return 0 }
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	mock := &klinesClient{}
+	store := klinestore.New(dir, binance.NewCustomClient(mock))
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	klines, err := store.Klines("BTCUSDT", binance.KlineInterval1min, t0, t0.Add(99*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, klines, 100)
+	require.Len(t, mock.requests, 1)
+
+	klines, err = store.Klines("BTCUSDT", binance.KlineInterval1min, t0.Add(10*time.Minute), t0.Add(19*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, klines, 10)
+	require.Equal(t, binance.TimeToMs(t0.Add(10*time.Minute)), klines[0].OpenTime)
+	require.Len(t, mock.requests, 1)
+
+	klines, err = store.Klines("BTCUSDT", binance.KlineInterval1min, t0.Add(50*time.Minute), t0.Add(149*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, klines, 100)
+	require.Len(t, mock.requests, 2)
+	// only the range after the last stored open time is requested
+	require.Equal(t, binance.TimeToMs(t0.Add(99*time.Minute))+1, mock.requests[1].StartTime)
+
+	ranges, err := store.Ranges("BTCUSDT", binance.KlineInterval1min)
+	require.NoError(t, err)
+	require.Equal(t, []klinestore.Range{{
+		From: binance.TimeToMs(t0),
+		To:   binance.TimeToMs(t0.Add(149 * time.Minute)),
+	}}, ranges)
+
+	// data is read back from disk with the original precision
+	reopened := klinestore.New(dir, binance.NewCustomClient(mock))
+	klines, err = reopened.Klines("BTCUSDT", binance.KlineInterval1min, t0.Add(-10*time.Minute), t0.Add(149*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, klines, 160)
+	require.Len(t, mock.requests, 3)
+	require.Equal(t, binance.TimeToMs(t0)-1, mock.requests[2].EndTime)
+	require.Equal(t, "1.50000000", binance.FormatDecimal(klines[0].ClosePrice))
+	require.Equal(t, 3, klines[159].Trades)
+
+	// klines are split into daily files, a request reads and writes only files of its range
+	files, err := filepath.Glob(filepath.Join(dir, "BTCUSDT", "1m", "*.csv"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "BTCUSDT", "1m", "BTCUSDT-1m-2023-12-31.csv"),
+		filepath.Join(dir, "BTCUSDT", "1m", "BTCUSDT-1m-2024-01-01.csv"),
+	}, files)
+	stored, err := os.ReadFile(files[1])
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(files[0]))
+	t1 := t0.Add(48 * time.Hour)
+	klines, err = store.Klines("BTCUSDT", binance.KlineInterval1min, t1, t1.Add(9*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, klines, 10)
+	require.Len(t, mock.requests, 4)
+	rewritten, err := os.ReadFile(files[1])
+	require.NoError(t, err)
+	require.Equal(t, stored, rewritten)
+	_, err = os.Stat(filepath.Join(dir, "BTCUSDT", "1m", "BTCUSDT-1m-2024-01-03.csv"))
+	require.NoError(t, err)
+
+	// the current kline isn't cached
+	now := time.Now()
+	klines, err = store.Klines("BTCUSDT", binance.KlineInterval1min, now.Add(-2*time.Minute), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, klines)
+	require.Less(t, klines[len(klines)-1].OpenTime, binance.TimeToMs(binance.KlineInterval1min.Truncate(now)))
+
+	_, err = store.Klines("BTCUSDT", binance.KlineInterval1min, t0, t0.Add(-time.Minute))
+	require.ErrorIs(t, err, binance.ErrInvalidTimeRange)
+}
@@ -0,0 +1,285 @@
+package binance
+
+import (
+	"math/bits"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+// NewMarketOrderReq creates a MARKET order buying or selling qty of the base asset
+func NewMarketOrderReq(symbol string, side OrderSide, qty decimal.Decimal) *OrderReq {
+	return &OrderReq{
+		Symbol:   symbol,
+		Side:     side,
+		Type:     OrderTypeMarket,
+		Quantity: formatOrderDecimal(qty),
+	}
+}
+
+// NewMarketQuoteOrderReq creates a MARKET order spending or receiving quoteQty of the quote asset
+func NewMarketQuoteOrderReq(symbol string, side OrderSide, quoteQty decimal.Decimal) *OrderReq {
+	return &OrderReq{
+		Symbol:        symbol,
+		Side:          side,
+		Type:          OrderTypeMarket,
+		QuoteQuantity: formatOrderDecimal(quoteQty),
+	}
+}
+
+// NewLimitOrderReq creates a LIMIT order, empty time in force means GTC
+func NewLimitOrderReq(symbol string, side OrderSide, tif TimeInForce, qty, price decimal.Decimal) *OrderReq {
+	return &OrderReq{
+		Symbol:      symbol,
+		Side:        side,
+		Type:        OrderTypeLimit,
+		TimeInForce: tif,
+		Quantity:    formatOrderDecimal(qty),
+		Price:       formatOrderDecimal(price),
+	}
+}
+
+// NewLimitMakerOrderReq creates a LIMIT_MAKER order which is rejected when it would immediately match
+func NewLimitMakerOrderReq(symbol string, side OrderSide, qty, price decimal.Decimal) *OrderReq {
+	return &OrderReq{
+		Symbol:   symbol,
+		Side:     side,
+		Type:     OrderTypeLimitMaker,
+		Quantity: formatOrderDecimal(qty),
+		Price:    formatOrderDecimal(price),
+	}
+}
+
+// NewStopLossOrderReq creates a STOP_LOSS order executed as MARKET once stopPrice is reached.
+// Zero stopPrice is allowed for trailing stops, see OrderReq.WithTrailingDelta
+func NewStopLossOrderReq(symbol string, side OrderSide, qty, stopPrice decimal.Decimal) *OrderReq {
+	return newStopOrderReq(symbol, side, OrderTypeStopLoss, qty, stopPrice)
+}
+
+// NewTakeProfitOrderReq creates a TAKE_PROFIT order executed as MARKET once stopPrice is reached.
+// Zero stopPrice is allowed for trailing stops, see OrderReq.WithTrailingDelta
+func NewTakeProfitOrderReq(symbol string, side OrderSide, qty, stopPrice decimal.Decimal) *OrderReq {
+	return newStopOrderReq(symbol, side, OrderTypeTakeProfit, qty, stopPrice)
+}
+
+// NewStopLossLimitOrderReq creates a STOP_LOSS_LIMIT order placed as LIMIT once stopPrice is reached.
+// Empty time in force means GTC, zero stopPrice is allowed for trailing stops
+func NewStopLossLimitOrderReq(symbol string, side OrderSide, tif TimeInForce, qty, price, stopPrice decimal.Decimal) *OrderReq {
+	req := newStopOrderReq(symbol, side, OrderTypeStopLossLimit, qty, stopPrice)
+	req.TimeInForce = tif
+	req.Price = formatOrderDecimal(price)
+
+	return req
+}
+
+// NewTakeProfitLimitOrderReq creates a TAKE_PROFIT_LIMIT order placed as LIMIT once stopPrice is reached.
+// Empty time in force means GTC, zero stopPrice is allowed for trailing stops
+func NewTakeProfitLimitOrderReq(symbol string, side OrderSide, tif TimeInForce, qty, price, stopPrice decimal.Decimal) *OrderReq {
+	req := newStopOrderReq(symbol, side, OrderTypeTakeProfitLimit, qty, stopPrice)
+	req.TimeInForce = tif
+	req.Price = formatOrderDecimal(price)
+
+	return req
+}
+
+func newStopOrderReq(symbol string, side OrderSide, typ OrderType, qty, stopPrice decimal.Decimal) *OrderReq {
+	return &OrderReq{
+		Symbol:    symbol,
+		Side:      side,
+		Type:      typ,
+		Quantity:  formatOrderDecimal(qty),
+		StopPrice: formatOrderDecimal(stopPrice),
+	}
+}
+
+// WithTrailingDelta makes the stop order trailing, delta is in basis points
+func (r *OrderReq) WithTrailingDelta(delta int64) *OrderReq {
+	r.TrailingDelta = delta
+
+	return r
+}
+
+// WithIcebergQty makes the limit order iceberg showing only qty in the order book
+func (r *OrderReq) WithIcebergQty(qty decimal.Decimal) *OrderReq {
+	r.IcebergQty = formatOrderDecimal(qty)
+
+	return r
+}
+
+// WithClientOrderID sets the unique id of the order
+func (r *OrderReq) WithClientOrderID(id string) *OrderReq {
+	r.NewClientOrderID = id
+
+	return r
+}
+
+// WithStrategy tags the order with the strategy id and type, type should be more than 1000000
+func (r *OrderReq) WithStrategy(id, typ int) *OrderReq {
+	r.StrategyID = id
+	r.StrategyType = typ
+
+	return r
+}
+
+// WithSelfTradePreventionMode overrides the symbol default self-trade prevention mode
+func (r *OrderReq) WithSelfTradePreventionMode(mode SelfTradePreventionMode) *OrderReq {
+	r.SelfTradePreventionMode = mode
+
+	return r
+}
+
+// formatOrderDecimal formats the request decimal, zero means the parameter isn't set
+func formatOrderDecimal(d decimal.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return FormatDecimal(d)
+}
+
+// Optional order parameters, which are allowed only for some order types
+const (
+	orderParamQuoteQty = 1 << iota
+	orderParamPrice
+	orderParamStopPrice
+	orderParamTrailingDelta
+	orderParamTimeInForce
+	orderParamIcebergQty
+)
+
+var orderParamNames = [...]string{"quoteOrderQty", "price", "stopPrice", "trailingDelta", "timeInForce", "icebergQty"}
+
+// orderParams are the parameters of a single order. Every order is validated by orderParams.validate:
+// new orders, order list legs and SOR orders
+type orderParams struct {
+	typ           OrderType
+	side          OrderSide
+	quantity      string
+	quoteQuantity string
+	price         string
+	stopPrice     string
+	trailingDelta int64
+	timeInForce   *TimeInForce
+	icebergQty    string
+	strategyType  int
+}
+
+func (r *OrderReq) orderParams() orderParams {
+	return orderParams{
+		typ:           r.Type,
+		side:          r.Side,
+		quantity:      r.Quantity,
+		quoteQuantity: r.QuoteQuantity,
+		price:         r.Price,
+		stopPrice:     r.StopPrice,
+		trailingDelta: r.TrailingDelta,
+		timeInForce:   &r.TimeInForce,
+		icebergQty:    r.IcebergQty,
+		strategyType:  r.StrategyType,
+	}
+}
+
+// set returns the set of optional parameters set in the order
+func (p orderParams) set() uint8 {
+	var set uint8
+	for i, ok := range [...]bool{
+		p.quoteQuantity != "",
+		p.price != "",
+		p.stopPrice != "",
+		p.trailingDelta != 0,
+		*p.timeInForce != "",
+		p.icebergQty != "",
+	} {
+		if ok {
+			set |= 1 << i
+		}
+	}
+
+	return set
+}
+
+// requireQuantity checks the quantity of stop orders and amendments
+func requireQuantity(qty string) error {
+	if qty == "" {
+		return ErrEmptyQuantity
+	}
+
+	return nil
+}
+
+// allowed checks the parameters required by the order type and returns the optional parameters allowed by it
+func (p orderParams) allowed() (uint8, error) {
+	switch p.typ { //nolint:exhaustive
+	case OrderTypeMarket:
+		if p.quantity == "" && p.quoteQuantity == "" {
+			return 0, ErrEmptyMarket
+		}
+		if p.quantity == "" {
+			return orderParamQuoteQty, nil
+		}
+
+		return 0, nil
+	case OrderTypeLimit, OrderTypeLimitMaker:
+		if p.quantity == "" || p.price == "" {
+			return 0, ErrEmptyLimit
+		}
+		if p.typ == OrderTypeLimit {
+			return orderParamPrice | orderParamIcebergQty | orderParamTimeInForce, nil
+		}
+
+		return orderParamPrice | orderParamIcebergQty, nil
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		if err := requireQuantity(p.quantity); err != nil {
+			return 0, err
+		}
+
+		return orderParamStopPrice | orderParamTrailingDelta, nil
+	case OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		if p.quantity == "" || p.price == "" {
+			return 0, ErrEmptyLimit
+		}
+
+		return orderParamPrice | orderParamStopPrice | orderParamTrailingDelta | orderParamTimeInForce | orderParamIcebergQty, nil
+	}
+
+	return 0, ErrInvalidOrderType
+}
+
+// validate checks the parameters required and forbidden by the order type and sets default time in force
+func (p orderParams) validate() error {
+	if p.side != OrderSideBuy && p.side != OrderSideSell {
+		return ErrInvalidOrderSide
+	}
+	allowed, err := p.allowed()
+	if err != nil {
+		return err
+	}
+	if forbidden := p.set() &^ allowed; forbidden != 0 {
+		return errors.Wrapf(ErrForbiddenOrderParam, "%s with %s order",
+			orderParamNames[bits.TrailingZeros8(forbidden)], p.typ)
+	}
+	if allowed&orderParamStopPrice != 0 && p.stopPrice == "" && p.trailingDelta == 0 {
+		return ErrEmptyStopPrice
+	}
+	if allowed&orderParamTimeInForce != 0 && *p.timeInForce == "" {
+		*p.timeInForce = TimeInForceGTC
+	}
+	if p.icebergQty != "" && *p.timeInForce != "" && *p.timeInForce != TimeInForceGTC {
+		return errors.Wrapf(ErrForbiddenOrderParam, "icebergQty with %s time in force", *p.timeInForce)
+	}
+	if p.strategyType > 0 && p.strategyType < MinStrategyType {
+		return ErrMinStrategyType
+	}
+
+	return nil
+}
+
+// validate checks the parameters required and forbidden by the order type and sets default time in force.
+// Every new order request goes through it
+func (r *OrderReq) validate() error {
+	if r.Symbol == "" {
+		return ErrEmptySymbol
+	}
+
+	return r.orderParams().validate()
+}
@@ -0,0 +1,86 @@
+package binance_test
+
+import (
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) TestOrderBuilders() {
+	var sent *binance.OrderReq
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointOrderTest, endpoint)
+		sent = data.(*binance.OrderReq)
+
+		return []byte(`{}`), nil
+	}
+	qty, price, stop := mustDecimal("0.5"), mustDecimal("100.10"), mustDecimal("95")
+
+	req := binance.NewLimitOrderReq("BTCUSDT", binance.OrderSideBuy, "", qty, price).
+		WithIcebergQty(mustDecimal("0.1")).
+		WithClientOrderID("my-order")
+	s.Require().NoError(s.api.NewOrderTest(req))
+	s.Require().Equal(&binance.OrderReq{
+		Symbol:           "BTCUSDT",
+		Side:             binance.OrderSideBuy,
+		Type:             binance.OrderTypeLimit,
+		TimeInForce:      binance.TimeInForceGTC,
+		Quantity:         "0.5",
+		Price:            "100.10",
+		IcebergQty:       "0.1",
+		NewClientOrderID: "my-order",
+	}, sent)
+
+	req = binance.NewStopLossOrderReq("BTCUSDT", binance.OrderSideSell, qty, mustDecimal("0")).WithTrailingDelta(200)
+	s.Require().NoError(s.api.NewOrderTest(req))
+	s.Require().Empty(sent.StopPrice)
+	s.Require().EqualValues(200, sent.TrailingDelta)
+
+	req = binance.NewTakeProfitLimitOrderReq("BTCUSDT", binance.OrderSideSell, binance.TimeInForceIOC, qty, price, stop)
+	s.Require().NoError(s.api.NewOrderTest(req))
+	s.Require().Equal("95", sent.StopPrice)
+	s.Require().Equal(binance.TimeInForceIOC, sent.TimeInForce)
+
+	s.Require().NoError(s.api.NewOrderTest(binance.NewMarketQuoteOrderReq("BTCUSDT", binance.OrderSideBuy, price)))
+	s.Require().Equal("100.10", sent.QuoteQuantity)
+	s.Require().Empty(sent.Quantity)
+}
+
+func (s *mockedTestSuite) TestOrderValidation() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		return []byte(`{}`), nil
+	}
+	qty, price, stop := mustDecimal("1"), mustDecimal("10"), mustDecimal("9")
+	for name, tc := range map[string]struct {
+		req *binance.OrderReq
+		err error
+	}{
+		"nil":                {nil, binance.ErrNilRequest},
+		"no symbol":          {binance.NewMarketOrderReq("", binance.OrderSideBuy, qty), binance.ErrEmptySymbol},
+		"no side":            {binance.NewMarketOrderReq("BTCUSDT", "", qty), binance.ErrInvalidOrderSide},
+		"no type":            {&binance.OrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Quantity: "1"}, binance.ErrInvalidOrderType},
+		"empty market":       {binance.NewMarketOrderReq("BTCUSDT", binance.OrderSideBuy, mustDecimal("0")), binance.ErrEmptyMarket},
+		"market price":       {&binance.OrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1", Price: "10"}, binance.ErrForbiddenOrderParam},
+		"market iceberg":     {binance.NewMarketOrderReq("BTCUSDT", binance.OrderSideBuy, qty).WithIcebergQty(qty), binance.ErrForbiddenOrderParam},
+		"market both qty":    {&binance.OrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1", QuoteQuantity: "10"}, binance.ErrForbiddenOrderParam},
+		"limit no price":     {binance.NewLimitOrderReq("BTCUSDT", binance.OrderSideBuy, "", qty, mustDecimal("0")), binance.ErrEmptyLimit},
+		"limit stop":         {binance.NewLimitOrderReq("BTCUSDT", binance.OrderSideBuy, "", qty, price).WithTrailingDelta(100), binance.ErrForbiddenOrderParam},
+		"maker tif":          {&binance.OrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimitMaker, TimeInForce: binance.TimeInForceGTC, Quantity: "1", Price: "10"}, binance.ErrForbiddenOrderParam},
+		"stop no quantity":   {binance.NewStopLossOrderReq("BTCUSDT", binance.OrderSideSell, mustDecimal("0"), stop), binance.ErrEmptyQuantity},
+		"stop no trigger":    {binance.NewStopLossOrderReq("BTCUSDT", binance.OrderSideSell, qty, mustDecimal("0")), binance.ErrEmptyStopPrice},
+		"stop iceberg":       {binance.NewStopLossOrderReq("BTCUSDT", binance.OrderSideSell, qty, stop).WithIcebergQty(qty), binance.ErrForbiddenOrderParam},
+		"stop limit trigger": {binance.NewStopLossLimitOrderReq("BTCUSDT", binance.OrderSideSell, "", qty, price, mustDecimal("0")), binance.ErrEmptyStopPrice},
+		"iceberg ioc":        {binance.NewLimitOrderReq("BTCUSDT", binance.OrderSideBuy, binance.TimeInForceIOC, qty, price).WithIcebergQty(qty), binance.ErrForbiddenOrderParam},
+		"strategy type":      {binance.NewLimitMakerOrderReq("BTCUSDT", binance.OrderSideBuy, qty, price).WithStrategy(1, 10), binance.ErrMinStrategyType},
+	} {
+		s.Require().ErrorIs(s.api.NewOrderTest(tc.req), tc.err, name)
+		if tc.req != nil {
+			_, err := s.api.NewOrderFull(tc.req)
+			s.Require().ErrorIs(err, tc.err, name)
+		}
+	}
+
+	_, err := s.api.CancelReplaceOrder(&binance.CancelReplaceOrderReq{
+		OrderReq:      *binance.NewTakeProfitOrderReq("BTCUSDT", binance.OrderSideSell, qty, mustDecimal("0")),
+		CancelOrderID: 1,
+	})
+	s.Require().ErrorIs(err, binance.ErrEmptyStopPrice)
+}
@@ -0,0 +1,558 @@
+package binance
+
+import (
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+type ContingencyType string
+
+const (
+	ContingencyTypeOCO ContingencyType = "OCO"
+	ContingencyTypeOTO ContingencyType = "OTO"
+)
+
+type ListStatusType string
+
+const (
+	ListStatusTypeResponse    ListStatusType = "RESPONSE"
+	ListStatusTypeExecStarted ListStatusType = "EXEC_STARTED"
+	ListStatusTypeAllDone     ListStatusType = "ALL_DONE"
+)
+
+type ListOrderStatus string
+
+const (
+	ListOrderStatusExecuting ListOrderStatus = "EXECUTING"
+	ListOrderStatusAllDone   ListOrderStatus = "ALL_DONE"
+	ListOrderStatusReject    ListOrderStatus = "REJECT"
+)
+
+// OCOOrderReq places a pair of orders where the execution of one cancels the other.
+// The above leg has a higher price than the below leg:
+// SELL lists take LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT above and STOP_LOSS or STOP_LOSS_LIMIT below,
+// BUY lists take STOP_LOSS or STOP_LOSS_LIMIT above and LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT below
+type OCOOrderReq struct {
+	Symbol                  string                  `url:"symbol"`
+	ListClientOrderID       string                  `url:"listClientOrderId,omitempty"`
+	Side                    OrderSide               `url:"side"`
+	Quantity                string                  `url:"quantity"`
+	AboveType               OrderType               `url:"aboveType"`
+	AboveClientOrderID      string                  `url:"aboveClientOrderId,omitempty"`
+	AboveIcebergQty         string                  `url:"aboveIcebergQty,omitempty"`
+	AbovePrice              string                  `url:"abovePrice,omitempty"`
+	AboveStopPrice          string                  `url:"aboveStopPrice,omitempty"`
+	AboveTrailingDelta      int64                   `url:"aboveTrailingDelta,omitempty"`
+	AboveTimeInForce        TimeInForce             `url:"aboveTimeInForce,omitempty"`
+	AboveStrategyID         int                     `url:"aboveStrategyId,omitempty"`
+	AboveStrategyType       int                     `url:"aboveStrategyType,omitempty"` // Should be more than 1000000
+	BelowType               OrderType               `url:"belowType"`
+	BelowClientOrderID      string                  `url:"belowClientOrderId,omitempty"`
+	BelowIcebergQty         string                  `url:"belowIcebergQty,omitempty"`
+	BelowPrice              string                  `url:"belowPrice,omitempty"`
+	BelowStopPrice          string                  `url:"belowStopPrice,omitempty"`
+	BelowTrailingDelta      int64                   `url:"belowTrailingDelta,omitempty"`
+	BelowTimeInForce        TimeInForce             `url:"belowTimeInForce,omitempty"`
+	BelowStrategyID         int                     `url:"belowStrategyId,omitempty"`
+	BelowStrategyType       int                     `url:"belowStrategyType,omitempty"` // Should be more than 1000000
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+}
+
+// OrderListLeg describes a single order of OTO and OTOCO lists, it's used to fill request fields of the leg
+type OrderListLeg struct {
+	Type          OrderType
+	Side          OrderSide
+	Quantity      string
+	Price         string
+	StopPrice     string
+	TrailingDelta int64
+	IcebergQty    string
+	TimeInForce   TimeInForce
+	ClientOrderID string
+	StrategyID    int
+	StrategyType  int // Should be more than 1000000
+}
+
+// OTOOrderReq places a working order which triggers the pending order once it's fully filled.
+// The working order is LIMIT or LIMIT_MAKER, the pending one can be of any type
+type OTOOrderReq struct {
+	Symbol                  string                  `url:"symbol"`
+	ListClientOrderID       string                  `url:"listClientOrderId,omitempty"`
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+	WorkingType             OrderType               `url:"workingType"`
+	WorkingSide             OrderSide               `url:"workingSide"`
+	WorkingClientOrderID    string                  `url:"workingClientOrderId,omitempty"`
+	WorkingPrice            string                  `url:"workingPrice"`
+	WorkingQuantity         string                  `url:"workingQuantity"`
+	WorkingIcebergQty       string                  `url:"workingIcebergQty,omitempty"`
+	WorkingTimeInForce      TimeInForce             `url:"workingTimeInForce,omitempty"`
+	WorkingStrategyID       int                     `url:"workingStrategyId,omitempty"`
+	WorkingStrategyType     int                     `url:"workingStrategyType,omitempty"`
+	PendingType             OrderType               `url:"pendingType"`
+	PendingSide             OrderSide               `url:"pendingSide"`
+	PendingClientOrderID    string                  `url:"pendingClientOrderId,omitempty"`
+	PendingPrice            string                  `url:"pendingPrice,omitempty"`
+	PendingStopPrice        string                  `url:"pendingStopPrice,omitempty"`
+	PendingTrailingDelta    int64                   `url:"pendingTrailingDelta,omitempty"`
+	PendingQuantity         string                  `url:"pendingQuantity"`
+	PendingIcebergQty       string                  `url:"pendingIcebergQty,omitempty"`
+	PendingTimeInForce      TimeInForce             `url:"pendingTimeInForce,omitempty"`
+	PendingStrategyID       int                     `url:"pendingStrategyId,omitempty"`
+	PendingStrategyType     int                     `url:"pendingStrategyType,omitempty"`
+}
+
+// NewOTOOrderReq creates OTO request from working and pending legs
+func NewOTOOrderReq(symbol string, working, pending OrderListLeg) *OTOOrderReq {
+	return &OTOOrderReq{
+		Symbol:               symbol,
+		WorkingType:          working.Type,
+		WorkingSide:          working.Side,
+		WorkingClientOrderID: working.ClientOrderID,
+		WorkingPrice:         working.Price,
+		WorkingQuantity:      working.Quantity,
+		WorkingIcebergQty:    working.IcebergQty,
+		WorkingTimeInForce:   working.TimeInForce,
+		WorkingStrategyID:    working.StrategyID,
+		WorkingStrategyType:  working.StrategyType,
+		PendingType:          pending.Type,
+		PendingSide:          pending.Side,
+		PendingClientOrderID: pending.ClientOrderID,
+		PendingPrice:         pending.Price,
+		PendingStopPrice:     pending.StopPrice,
+		PendingTrailingDelta: pending.TrailingDelta,
+		PendingQuantity:      pending.Quantity,
+		PendingIcebergQty:    pending.IcebergQty,
+		PendingTimeInForce:   pending.TimeInForce,
+		PendingStrategyID:    pending.StrategyID,
+		PendingStrategyType:  pending.StrategyType,
+	}
+}
+
+// OTOCOOrderReq places a working order which triggers a pending OCO pair once it's fully filled.
+// Pending legs share side and quantity and follow the same leg type rules as OCOOrderReq
+type OTOCOOrderReq struct {
+	Symbol                    string                  `url:"symbol"`
+	ListClientOrderID         string                  `url:"listClientOrderId,omitempty"`
+	OrderRespType             OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode   SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+	WorkingType               OrderType               `url:"workingType"`
+	WorkingSide               OrderSide               `url:"workingSide"`
+	WorkingClientOrderID      string                  `url:"workingClientOrderId,omitempty"`
+	WorkingPrice              string                  `url:"workingPrice"`
+	WorkingQuantity           string                  `url:"workingQuantity"`
+	WorkingIcebergQty         string                  `url:"workingIcebergQty,omitempty"`
+	WorkingTimeInForce        TimeInForce             `url:"workingTimeInForce,omitempty"`
+	WorkingStrategyID         int                     `url:"workingStrategyId,omitempty"`
+	WorkingStrategyType       int                     `url:"workingStrategyType,omitempty"`
+	PendingSide               OrderSide               `url:"pendingSide"`
+	PendingQuantity           string                  `url:"pendingQuantity"`
+	PendingAboveType          OrderType               `url:"pendingAboveType"`
+	PendingAboveClientOrderID string                  `url:"pendingAboveClientOrderId,omitempty"`
+	PendingAbovePrice         string                  `url:"pendingAbovePrice,omitempty"`
+	PendingAboveStopPrice     string                  `url:"pendingAboveStopPrice,omitempty"`
+	PendingAboveTrailingDelta int64                   `url:"pendingAboveTrailingDelta,omitempty"`
+	PendingAboveIcebergQty    string                  `url:"pendingAboveIcebergQty,omitempty"`
+	PendingAboveTimeInForce   TimeInForce             `url:"pendingAboveTimeInForce,omitempty"`
+	PendingAboveStrategyID    int                     `url:"pendingAboveStrategyId,omitempty"`
+	PendingAboveStrategyType  int                     `url:"pendingAboveStrategyType,omitempty"`
+	PendingBelowType          OrderType               `url:"pendingBelowType"`
+	PendingBelowClientOrderID string                  `url:"pendingBelowClientOrderId,omitempty"`
+	PendingBelowPrice         string                  `url:"pendingBelowPrice,omitempty"`
+	PendingBelowStopPrice     string                  `url:"pendingBelowStopPrice,omitempty"`
+	PendingBelowTrailingDelta int64                   `url:"pendingBelowTrailingDelta,omitempty"`
+	PendingBelowIcebergQty    string                  `url:"pendingBelowIcebergQty,omitempty"`
+	PendingBelowTimeInForce   TimeInForce             `url:"pendingBelowTimeInForce,omitempty"`
+	PendingBelowStrategyID    int                     `url:"pendingBelowStrategyId,omitempty"`
+	PendingBelowStrategyType  int                     `url:"pendingBelowStrategyType,omitempty"`
+}
+
+// NewOTOCOOrderReq creates OTOCO request from working and pending legs.
+// Pending side and quantity are taken from the above leg, the below leg must have the same or empty ones
+func NewOTOCOOrderReq(symbol string, working, above, below OrderListLeg) (*OTOCOOrderReq, error) {
+	if below.Side != "" && below.Side != above.Side || below.Quantity != "" && below.Quantity != above.Quantity {
+		return nil, ErrInvalidOrderListLegs
+	}
+
+	return &OTOCOOrderReq{
+		Symbol:                    symbol,
+		WorkingType:               working.Type,
+		WorkingSide:               working.Side,
+		WorkingClientOrderID:      working.ClientOrderID,
+		WorkingPrice:              working.Price,
+		WorkingQuantity:           working.Quantity,
+		WorkingIcebergQty:         working.IcebergQty,
+		WorkingTimeInForce:        working.TimeInForce,
+		WorkingStrategyID:         working.StrategyID,
+		WorkingStrategyType:       working.StrategyType,
+		PendingSide:               above.Side,
+		PendingQuantity:           above.Quantity,
+		PendingAboveType:          above.Type,
+		PendingAboveClientOrderID: above.ClientOrderID,
+		PendingAbovePrice:         above.Price,
+		PendingAboveStopPrice:     above.StopPrice,
+		PendingAboveTrailingDelta: above.TrailingDelta,
+		PendingAboveIcebergQty:    above.IcebergQty,
+		PendingAboveTimeInForce:   above.TimeInForce,
+		PendingAboveStrategyID:    above.StrategyID,
+		PendingAboveStrategyType:  above.StrategyType,
+		PendingBelowType:          below.Type,
+		PendingBelowClientOrderID: below.ClientOrderID,
+		PendingBelowPrice:         below.Price,
+		PendingBelowStopPrice:     below.StopPrice,
+		PendingBelowTrailingDelta: below.TrailingDelta,
+		PendingBelowIcebergQty:    below.IcebergQty,
+		PendingBelowTimeInForce:   below.TimeInForce,
+		PendingBelowStrategyID:    below.StrategyID,
+		PendingBelowStrategyType:  below.StrategyType,
+	}, nil
+}
+
+// OrderListOrder identifies an order of the order list
+type OrderListOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderID       uint64 `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+}
+
+type OrderList struct {
+	OrderListID       int64            `json:"orderListId"`
+	ContingencyType   ContingencyType  `json:"contingencyType"`
+	ListStatusType    ListStatusType   `json:"listStatusType"`
+	ListOrderStatus   ListOrderStatus  `json:"listOrderStatus"`
+	ListClientOrderID string           `json:"listClientOrderId"`
+	TransactionTime   uint64           `json:"transactionTime"`
+	Symbol            string           `json:"symbol"`
+	Orders            []OrderListOrder `json:"orders"`
+}
+
+// OrderListReport is the state of a placed order list leg
+type OrderListReport struct {
+	OrderRespResult
+	StopPrice     decimal.Decimal `json:"stopPrice"`
+	IcebergQty    decimal.Decimal `json:"icebergQty"`
+	TrailingDelta int64           `json:"trailingDelta,omitempty"`
+}
+
+type OrderListResp struct {
+	OrderList
+	OrderReports []OrderListReport `json:"orderReports"`
+}
+
+// Report returns the report of the list order with the given id or nil
+func (r *OrderListResp) Report(orderID uint64) *OrderListReport {
+	for i := range r.OrderReports {
+		if r.OrderReports[i].OrderID == orderID {
+			return &r.OrderReports[i]
+		}
+	}
+
+	return nil
+}
+
+// QueryReq creates request to query the order list status
+func (l *OrderList) QueryReq() *QueryOrderListReq {
+	return &QueryOrderListReq{OrderListID: l.OrderListID}
+}
+
+// CancelReq creates request to cancel the whole order list
+func (l *OrderList) CancelReq() *CancelOrderListReq {
+	return &CancelOrderListReq{Symbol: l.Symbol, OrderListID: l.OrderListID}
+}
+
+// Remark: Either OrderListID or OrigClientOrderID must be set
+type QueryOrderListReq struct {
+	OrderListID       int64  `url:"orderListId,omitempty"`
+	OrigClientOrderID string `url:"origClientOrderId,omitempty"`
+}
+
+// Remark: Either OrderListID or ListClientOrderID must be set
+type CancelOrderListReq struct {
+	Symbol            string `url:"symbol"`
+	OrderListID       int64  `url:"orderListId,omitempty"`
+	ListClientOrderID string `url:"listClientOrderId,omitempty"`
+	NewClientOrderID  string `url:"newClientOrderId,omitempty"`
+}
+
+type CancelOrderList struct {
+	OrderList
+	OrderReports []CancelOrder `json:"orderReports"`
+}
+
+// AllOrderListsReq represents the request used for querying order lists
+// Remark: If FromID is set, StartTime and EndTime can't be used
+type AllOrderListsReq struct {
+	FromID    int64  `url:"fromId,omitempty"`
+	StartTime uint64 `url:"startTime,omitempty"`
+	EndTime   uint64 `url:"endTime,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+// validateLeg checks the leg by the rules of a single order, invalid leg side or type is reported
+// as ErrInvalidOrderListLegs
+func validateLeg(l orderParams) error {
+	err := l.validate()
+	if errors.Is(err, ErrInvalidOrderSide) || errors.Is(err, ErrInvalidOrderType) {
+		return ErrInvalidOrderListLegs
+	}
+
+	return err
+}
+
+func isStopLoss(t OrderType) bool {
+	return t == OrderTypeStopLoss || t == OrderTypeStopLossLimit
+}
+
+// validateOCOLegs checks leg types against the side and required parameters of both legs
+func validateOCOLegs(above, below orderParams) error {
+	for _, t := range []OrderType{above.typ, below.typ} {
+		if t == OrderTypeLimit || t == OrderTypeMarket {
+			return ErrInvalidOrderListLegs
+		}
+	}
+	// stop loss leg is triggered when the price moves against the position
+	stopAbove := above.side == OrderSideBuy
+	if isStopLoss(above.typ) != stopAbove || isStopLoss(below.typ) == stopAbove {
+		return ErrInvalidOrderListLegs
+	}
+	if err := validateLeg(above); err != nil {
+		return err
+	}
+
+	return validateLeg(below)
+}
+
+func (r *OCOOrderReq) validate() error {
+	return validateOCOLegs(orderParams{
+		typ:           r.AboveType,
+		side:          r.Side,
+		quantity:      r.Quantity,
+		price:         r.AbovePrice,
+		stopPrice:     r.AboveStopPrice,
+		trailingDelta: r.AboveTrailingDelta,
+		timeInForce:   &r.AboveTimeInForce,
+		icebergQty:    r.AboveIcebergQty,
+		strategyType:  r.AboveStrategyType,
+	}, orderParams{
+		typ:           r.BelowType,
+		side:          r.Side,
+		quantity:      r.Quantity,
+		price:         r.BelowPrice,
+		stopPrice:     r.BelowStopPrice,
+		trailingDelta: r.BelowTrailingDelta,
+		timeInForce:   &r.BelowTimeInForce,
+		icebergQty:    r.BelowIcebergQty,
+		strategyType:  r.BelowStrategyType,
+	})
+}
+
+// validateWorkingLeg checks the working order of OTO and OTOCO lists
+func validateWorkingLeg(l orderParams) error {
+	if l.typ != OrderTypeLimit && l.typ != OrderTypeLimitMaker {
+		return ErrInvalidOrderListLegs
+	}
+
+	return validateLeg(l)
+}
+
+func (r *OTOOrderReq) validate() error {
+	err := validateWorkingLeg(orderParams{
+		typ:          r.WorkingType,
+		side:         r.WorkingSide,
+		quantity:     r.WorkingQuantity,
+		price:        r.WorkingPrice,
+		timeInForce:  &r.WorkingTimeInForce,
+		icebergQty:   r.WorkingIcebergQty,
+		strategyType: r.WorkingStrategyType,
+	})
+	if err != nil {
+		return err
+	}
+
+	return validateLeg(orderParams{
+		typ:           r.PendingType,
+		side:          r.PendingSide,
+		quantity:      r.PendingQuantity,
+		price:         r.PendingPrice,
+		stopPrice:     r.PendingStopPrice,
+		trailingDelta: r.PendingTrailingDelta,
+		timeInForce:   &r.PendingTimeInForce,
+		icebergQty:    r.PendingIcebergQty,
+		strategyType:  r.PendingStrategyType,
+	})
+}
+
+func (r *OTOCOOrderReq) validate() error {
+	err := validateWorkingLeg(orderParams{
+		typ:          r.WorkingType,
+		side:         r.WorkingSide,
+		quantity:     r.WorkingQuantity,
+		price:        r.WorkingPrice,
+		timeInForce:  &r.WorkingTimeInForce,
+		icebergQty:   r.WorkingIcebergQty,
+		strategyType: r.WorkingStrategyType,
+	})
+	if err != nil {
+		return err
+	}
+
+	return validateOCOLegs(orderParams{
+		typ:           r.PendingAboveType,
+		side:          r.PendingSide,
+		quantity:      r.PendingQuantity,
+		price:         r.PendingAbovePrice,
+		stopPrice:     r.PendingAboveStopPrice,
+		trailingDelta: r.PendingAboveTrailingDelta,
+		timeInForce:   &r.PendingAboveTimeInForce,
+		icebergQty:    r.PendingAboveIcebergQty,
+		strategyType:  r.PendingAboveStrategyType,
+	}, orderParams{
+		typ:           r.PendingBelowType,
+		side:          r.PendingSide,
+		quantity:      r.PendingQuantity,
+		price:         r.PendingBelowPrice,
+		stopPrice:     r.PendingBelowStopPrice,
+		trailingDelta: r.PendingBelowTrailingDelta,
+		timeInForce:   &r.PendingBelowTimeInForce,
+		icebergQty:    r.PendingBelowIcebergQty,
+		strategyType:  r.PendingBelowStrategyType,
+	})
+}
+
+// NewOCOOrder places a one-cancels-the-other order list
+func (c *Client) NewOCOOrder(req *OCOOrderReq) (*OrderListResp, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrderListOCO, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OrderListResp{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// NewOTOOrder places a one-triggers-the-other order list
+func (c *Client) NewOTOOrder(req *OTOOrderReq) (*OrderListResp, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrderListOTO, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OrderListResp{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// NewOTOCOOrder places a one-triggers-a-one-cancels-the-other order list
+func (c *Client) NewOTOCOOrder(req *OTOCOOrderReq) (*OrderListResp, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrderListOTOCO, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OrderListResp{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryOrderList checks an order list's status
+func (c *Client) QueryOrderList(req *QueryOrderListReq) (*OrderList, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.OrderListID == 0 && req.OrigClientOrderID == "" {
+		return nil, ErrEmptyOrderListID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrderList, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OrderList{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOrderList cancels an entire order list
+func (c *Client) CancelOrderList(req *CancelOrderListReq) (*CancelOrderList, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.OrderListID == 0 && req.ListClientOrderID == "" {
+		return nil, ErrEmptyOrderListID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOrderList, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &CancelOrderList{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// AllOrderLists get all account order lists
+func (c *Client) AllOrderLists(req *AllOrderListsReq) ([]*OrderList, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.FromID != 0 && (req.StartTime != 0 || req.EndTime != 0) {
+		return nil, ErrInvalidTimeRange
+	}
+	if req.Limit <= 0 || req.Limit > MaxOrderLimit {
+		req.Limit = DefaultOrderLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrderListsAll, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OrderList
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// OpenOrderLists get all open order lists
+func (c *Client) OpenOrderLists() ([]*OrderList, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenOrderLists, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OrderList
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
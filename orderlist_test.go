@@ -0,0 +1,248 @@
+package binance_test
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/ugi1/binance-api"
+)
+
+const ocoOrderResponse = `{
+  "orderListId": 1,
+  "contingencyType": "OCO",
+  "listStatusType": "EXEC_STARTED",
+  "listOrderStatus": "EXECUTING",
+  "listClientOrderId": "lH1YDkuQKWiXVXHPSKYEIp",
+  "transactionTime": 1710485608839,
+  "symbol": "LTCBTC",
+  "orders": [
+    {"symbol": "LTCBTC", "orderId": 10, "clientOrderId": "44nZvqpemY7sVYgPYbvPih"},
+    {"symbol": "LTCBTC", "orderId": 11, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK"}
+  ],
+  "orderReports": [
+    {
+      "symbol": "LTCBTC", "orderId": 10, "orderListId": 1, "clientOrderId": "44nZvqpemY7sVYgPYbvPih",
+      "transactTime": 1710485608839, "price": "1.00000000", "origQty": "5.00000000", "executedQty": "0.00000000",
+      "cummulativeQuoteQty": "0.00000000", "status": "NEW", "timeInForce": "GTC", "type": "STOP_LOSS_LIMIT",
+      "side": "SELL", "stopPrice": "1.00000000"
+    },
+    {
+      "symbol": "LTCBTC", "orderId": 11, "orderListId": 1, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK",
+      "transactTime": 1710485608839, "price": "3.00000000", "origQty": "5.00000000", "executedQty": "0.00000000",
+      "cummulativeQuoteQty": "0.00000000", "status": "NEW", "timeInForce": "GTC", "type": "LIMIT_MAKER",
+      "side": "SELL"
+    }
+  ]
+}`
+
+func (s *mockedTestSuite) TestNewOCOOrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(binance.EndpointOrderListOCO, endpoint)
+		s.Require().True(sign)
+		req := data.(*binance.OCOOrderReq)
+		s.Require().Equal(binance.TimeInForceGTC, req.BelowTimeInForce)
+		s.Require().Empty(req.AboveTimeInForce)
+
+		return []byte(ocoOrderResponse), nil
+	}
+	resp, err := s.api.NewOCOOrder(&binance.OCOOrderReq{
+		Symbol:         "LTCBTC",
+		Side:           binance.OrderSideSell,
+		Quantity:       "5",
+		AboveType:      binance.OrderTypeLimitMaker,
+		AbovePrice:     "3",
+		BelowType:      binance.OrderTypeStopLossLimit,
+		BelowPrice:     "1",
+		BelowStopPrice: "1",
+	})
+	s.Require().NoError(err)
+	s.Require().EqualValues(1, resp.OrderListID)
+	s.Require().Equal(binance.ContingencyTypeOCO, resp.ContingencyType)
+	s.Require().Equal(binance.ListOrderStatusExecuting, resp.ListOrderStatus)
+	s.Require().Len(resp.Orders, 2)
+	s.Require().Len(resp.OrderReports, 2)
+	s.Require().Equal(binance.OrderTypeStopLossLimit, resp.OrderReports[0].Type)
+	s.Require().Equal("1.00000000", binance.FormatDecimal(resp.OrderReports[0].StopPrice))
+}
+
+func (s *mockedTestSuite) TestNewOCOOrderValidation() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.FailNow("request must not be sent")
+
+		return nil, nil
+	}
+	valid := func() *binance.OCOOrderReq {
+		return &binance.OCOOrderReq{
+			Symbol:         "LTCBTC",
+			Side:           binance.OrderSideBuy,
+			Quantity:       "5",
+			AboveType:      binance.OrderTypeStopLoss,
+			AboveStopPrice: "3",
+			BelowType:      binance.OrderTypeTakeProfitLimit,
+			BelowPrice:     "1",
+			BelowStopPrice: "1.1",
+		}
+	}
+	for name, tc := range map[string]struct {
+		modify func(r *binance.OCOOrderReq)
+		err    error
+	}{
+		"no symbol":          {func(r *binance.OCOOrderReq) { r.Symbol = "" }, binance.ErrEmptySymbol},
+		"no quantity":        {func(r *binance.OCOOrderReq) { r.Quantity = "" }, binance.ErrEmptyQuantity},
+		"no side":            {func(r *binance.OCOOrderReq) { r.Side = "" }, binance.ErrInvalidOrderListLegs},
+		"sell with buy legs": {func(r *binance.OCOOrderReq) { r.Side = binance.OrderSideSell }, binance.ErrInvalidOrderListLegs},
+		"two stop losses":    {func(r *binance.OCOOrderReq) { r.BelowType = binance.OrderTypeStopLossLimit }, binance.ErrInvalidOrderListLegs},
+		"limit leg":          {func(r *binance.OCOOrderReq) { r.BelowType = binance.OrderTypeLimit }, binance.ErrInvalidOrderListLegs},
+		"no stop price":      {func(r *binance.OCOOrderReq) { r.AboveStopPrice = "" }, binance.ErrEmptyStopPrice},
+		"no limit price":     {func(r *binance.OCOOrderReq) { r.BelowPrice = "" }, binance.ErrEmptyLimit},
+		"strategy type":      {func(r *binance.OCOOrderReq) { r.AboveStrategyType = 1 }, binance.ErrMinStrategyType},
+		"stop loss price":    {func(r *binance.OCOOrderReq) { r.AbovePrice = "3.1" }, binance.ErrForbiddenOrderParam},
+		"stop loss iceberg":  {func(r *binance.OCOOrderReq) { r.AboveIcebergQty = "1" }, binance.ErrForbiddenOrderParam},
+		"iceberg fok":        {func(r *binance.OCOOrderReq) { r.BelowIcebergQty = "1"; r.BelowTimeInForce = binance.TimeInForceFOK }, binance.ErrForbiddenOrderParam},
+	} {
+		req := valid()
+		tc.modify(req)
+		_, err := s.api.NewOCOOrder(req)
+		s.Require().ErrorIs(err, tc.err, name)
+	}
+	_, err := s.api.NewOCOOrder(nil)
+	s.Require().ErrorIs(err, binance.ErrNilRequest)
+}
+
+func (s *mockedTestSuite) TestOrderListLifecycle() {
+	var resp binance.OrderListResp
+	s.Require().NoError(json.Unmarshal([]byte(ocoOrderResponse), &resp))
+	list := resp.OrderList
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointOrderList, endpoint)
+		s.Require().EqualValues(1, data.(*binance.QueryOrderListReq).OrderListID)
+
+		return json.Marshal(list)
+	}
+	queried, err := s.api.QueryOrderList(&binance.QueryOrderListReq{OrderListID: 1})
+	s.Require().NoError(err)
+	s.requireEqualJSON(list, queried)
+	_, err = s.api.QueryOrderList(&binance.QueryOrderListReq{})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderListID)
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointOpenOrderLists, endpoint)
+		s.Require().Nil(data)
+
+		return json.Marshal([]binance.OrderList{list})
+	}
+	open, err := s.api.OpenOrderLists()
+	s.Require().NoError(err)
+	s.Require().Len(open, 1)
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointOrderListsAll, endpoint)
+		s.Require().Equal(binance.DefaultOrderLimit, data.(*binance.AllOrderListsReq).Limit)
+
+		return json.Marshal([]binance.OrderList{list, list})
+	}
+	all, err := s.api.AllOrderLists(&binance.AllOrderListsReq{FromID: 1})
+	s.Require().NoError(err)
+	s.Require().Len(all, 2)
+	_, err = s.api.AllOrderLists(&binance.AllOrderListsReq{FromID: 1, StartTime: 1})
+	s.Require().ErrorIs(err, binance.ErrInvalidTimeRange)
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodDelete, method)
+		s.Require().Equal(binance.EndpointOrderList, endpoint)
+		canceled := binance.CancelOrderList{OrderList: list}
+		canceled.ListStatusType = binance.ListStatusTypeAllDone
+		canceled.ListOrderStatus = binance.ListOrderStatusAllDone
+		for _, o := range list.Orders {
+			canceled.OrderReports = append(canceled.OrderReports, binance.CancelOrder{
+				Symbol:      o.Symbol,
+				OrderID:     o.OrderID,
+				OrderListID: list.OrderListID,
+				Status:      binance.OrderStatusCanceled,
+			})
+		}
+
+		return json.Marshal(canceled)
+	}
+	canceled, err := s.api.CancelOrderList(&binance.CancelOrderListReq{Symbol: "LTCBTC", OrderListID: 1})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.ListOrderStatusAllDone, canceled.ListOrderStatus)
+	s.Require().Len(canceled.OrderReports, 2)
+	s.Require().Equal(binance.OrderStatusCanceled, canceled.OrderReports[1].Status)
+	_, err = s.api.CancelOrderList(&binance.CancelOrderListReq{Symbol: "LTCBTC"})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderListID)
+}
+
+func (s *mockedTestSuite) TestNewOTOOrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointOrderListOTO, endpoint)
+		req := data.(*binance.OTOOrderReq)
+		s.Require().Equal(binance.TimeInForceGTC, req.WorkingTimeInForce)
+		s.Require().Equal("1.5", req.PendingStopPrice)
+
+		return []byte(`{"orderListId":2,"contingencyType":"OTO","listStatusType":"EXEC_STARTED","listOrderStatus":"EXECUTING",` +
+			`"symbol":"LTCBTC","orders":[{"symbol":"LTCBTC","orderId":20},{"symbol":"LTCBTC","orderId":21}],` +
+			`"orderReports":[{"symbol":"LTCBTC","orderId":20,"orderListId":2,"status":"NEW","type":"LIMIT","side":"BUY","price":"1.00000000"},` +
+			`{"symbol":"LTCBTC","orderId":21,"orderListId":2,"status":"PENDING_NEW","type":"STOP_LOSS","side":"SELL","stopPrice":"1.50000000"}]}`), nil
+	}
+	working := binance.OrderListLeg{Type: binance.OrderTypeLimit, Side: binance.OrderSideBuy, Quantity: "1", Price: "1"}
+	pending := binance.OrderListLeg{Type: binance.OrderTypeStopLoss, Side: binance.OrderSideSell, Quantity: "1", StopPrice: "1.5"}
+	resp, err := s.api.NewOTOOrder(binance.NewOTOOrderReq("LTCBTC", working, pending))
+	s.Require().NoError(err)
+	s.Require().Equal(binance.ContingencyTypeOTO, resp.ContingencyType)
+	s.Require().Nil(resp.Report(1))
+	s.Require().Equal("1.50000000", binance.FormatDecimal(resp.Report(21).StopPrice))
+	s.Require().Equal(&binance.CancelOrderListReq{Symbol: "LTCBTC", OrderListID: 2}, resp.CancelReq())
+	s.Require().Equal(&binance.QueryOrderListReq{OrderListID: 2}, resp.QueryReq())
+
+	working.Type = binance.OrderTypeStopLossLimit
+	_, err = s.api.NewOTOOrder(binance.NewOTOOrderReq("LTCBTC", working, pending))
+	s.Require().ErrorIs(err, binance.ErrInvalidOrderListLegs)
+	working.Type = binance.OrderTypeLimitMaker
+	pending.StopPrice = ""
+	_, err = s.api.NewOTOOrder(binance.NewOTOOrderReq("LTCBTC", working, pending))
+	s.Require().ErrorIs(err, binance.ErrEmptyStopPrice)
+	pending.Side = ""
+	_, err = s.api.NewOTOOrder(binance.NewOTOOrderReq("LTCBTC", working, pending))
+	s.Require().ErrorIs(err, binance.ErrInvalidOrderListLegs)
+	// legs follow the rules of single orders
+	pending.Side = binance.OrderSideSell
+	pending.StopPrice = "1.5"
+	working.TimeInForce = binance.TimeInForceGTC
+	_, err = s.api.NewOTOOrder(binance.NewOTOOrderReq("LTCBTC", working, pending))
+	s.Require().ErrorIs(err, binance.ErrForbiddenOrderParam)
+}
+
+func (s *mockedTestSuite) TestNewOTOCOOrder() {
+	var sent *binance.OTOCOOrderReq
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointOrderListOTOCO, endpoint)
+		sent = data.(*binance.OTOCOOrderReq)
+
+		return []byte(`{"orderListId":3,"contingencyType":"OTO","symbol":"LTCBTC","orders":[{"orderId":30},{"orderId":31},{"orderId":32}]}`), nil
+	}
+	working := binance.OrderListLeg{Type: binance.OrderTypeLimit, Side: binance.OrderSideBuy, Quantity: "1", Price: "2"}
+	above := binance.OrderListLeg{Type: binance.OrderTypeLimitMaker, Side: binance.OrderSideSell, Quantity: "1", Price: "3"}
+	below := binance.OrderListLeg{Type: binance.OrderTypeStopLossLimit, Price: "1", StopPrice: "1.1"}
+	req, err := binance.NewOTOCOOrderReq("LTCBTC", working, above, below)
+	s.Require().NoError(err)
+	resp, err := s.api.NewOTOCOOrder(req)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Orders, 3)
+	s.Require().Equal(binance.OrderSideSell, sent.PendingSide)
+	s.Require().Equal(binance.TimeInForceGTC, sent.PendingBelowTimeInForce)
+
+	below.Side = binance.OrderSideBuy
+	_, err = binance.NewOTOCOOrderReq("LTCBTC", working, above, below)
+	s.Require().ErrorIs(err, binance.ErrInvalidOrderListLegs)
+
+	below.Side = ""
+	below.Type = binance.OrderTypeTakeProfitLimit
+	req, err = binance.NewOTOCOOrderReq("LTCBTC", working, above, below)
+	s.Require().NoError(err)
+	_, err = s.api.NewOTOCOOrder(req)
+	s.Require().ErrorIs(err, binance.ErrInvalidOrderListLegs)
+}
@@ -0,0 +1,684 @@
+// Package ordertracker keeps the local state of orders built from REST responses and user data stream events.
+//
+// Every order goes through NEW → PARTIALLY_FILLED → FILLED, CANCELED, EXPIRED or REJECTED. Updates may come
+// from several sources in any order and more than once, so an update is applied only when it doesn't
+// move the order back: the executed quantity never decreases and a final status is never left.
+// Fills are deduplicated by trade id.
+//
+//	t := ordertracker.New()
+//	t.Subscribe(ordertracker.Filter{Symbol: "BTCUSDT"}, func(c ordertracker.Change) {
+//		// c.Order was updated
+//	})
+//	resp, err := client.NewOrderFull(req)
+//	if err == nil {
+//		t.ApplyOrderResp(resp)
+//	}
+//	for {
+//		e, err := stream.Read()
+//		...
+//		if u, ok := e.(*ws.OrderUpdateEvent); ok {
+//			t.ApplyOrderUpdate(u)
+//		}
+//	}
+package ordertracker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/ws"
+)
+
+// Fill is a single trade of the order
+type Fill struct {
+	TradeID         int64
+	Price           decimal.Decimal
+	Qty             decimal.Decimal
+	Commission      decimal.Decimal
+	CommissionAsset string
+	Time            uint64 // Time is zero for fills of REST responses
+	Maker           bool
+}
+
+// Order is the tracked state of a single order
+type Order struct {
+	Symbol        string
+	OrderID       uint64 // OrderID is zero until the order is acknowledged
+	OrderListID   int64
+	ClientOrderID string // ClientOrderID is the id the order was placed with
+	Side          binance.OrderSide
+	Type          binance.OrderType
+	TimeInForce   binance.TimeInForce
+	Price         decimal.Decimal
+	StopPrice     decimal.Decimal
+	OrigQty       decimal.Decimal
+	OrigQuoteQty  decimal.Decimal
+	StrategyID    int
+	StrategyType  int
+
+	Status              binance.OrderStatus // Status is empty until the order is acknowledged
+	RejectReason        binance.OrderFailure
+	ExecutedQty         decimal.Decimal
+	CummulativeQuoteQty decimal.Decimal
+	Fills               []Fill
+	Commissions         map[string]decimal.Decimal // Commissions are the fill commissions by asset
+	CreateTime          uint64
+	UpdateTime          uint64
+}
+
+// Open reports whether the order can still be filled
+func (o *Order) Open() bool {
+	return rank(o.Status) < rankFinal
+}
+
+// AvgPrice returns the average fill price, zero when the order isn't filled
+func (o *Order) AvgPrice() decimal.Decimal {
+	if o.ExecutedQty.IsZero() {
+		return decimal.Zero
+	}
+
+	return o.CummulativeQuoteQty.Div(o.ExecutedQty)
+}
+
+func (o *Order) clone() Order {
+	c := *o
+	c.Fills = append([]Fill(nil), o.Fills...)
+	c.Commissions = make(map[string]decimal.Decimal, len(o.Commissions))
+	for asset, v := range o.Commissions {
+		c.Commissions[asset] = v
+	}
+
+	return c
+}
+
+const (
+	rankUnknown = iota
+	rankPending
+	rankNew
+	rankPartial
+	rankFinal
+)
+
+// rank orders statuses, an order never moves to a status of a lower rank
+func rank(s binance.OrderStatus) int {
+	switch s { //nolint:exhaustive
+	case "":
+		return rankUnknown
+	case binance.OrderStatusPendingNew:
+		return rankPending
+	case binance.OrderStatusNew:
+		return rankNew
+	case binance.OrderStatusPartial, binance.OrderStatusPending:
+		return rankPartial
+	default:
+		return rankFinal
+	}
+}
+
+// Change is a single order update delivered to subscribers
+type Change struct {
+	Prev  *Order // Prev is nil when the order wasn't tracked before
+	Order Order
+	Fills []Fill // Fills are fills added by the update, one order response can add several
+}
+
+// Filter selects orders, zero fields match any order
+type Filter struct {
+	Symbol     string
+	StrategyID int
+	Tag        string // Tag is the strategy tag of client order ids generated by binance.ClientOrderIDGenerator
+	Open       bool   // Open selects only orders which can still be filled
+}
+
+// Match reports whether the order is selected by the filter
+func (f *Filter) Match(o *Order) bool {
+	if f.Symbol != "" && f.Symbol != o.Symbol {
+		return false
+	}
+	if f.StrategyID != 0 && f.StrategyID != o.StrategyID {
+		return false
+	}
+	if f.Open && !o.Open() {
+		return false
+	}
+	if f.Tag != "" {
+		id, err := binance.ParseClientOrderID(o.ClientOrderID)
+		if err != nil || id.Tag != f.Tag {
+			return false
+		}
+	}
+
+	return true
+}
+
+type orderKey struct {
+	symbol string
+	id     uint64
+}
+
+type subscription struct {
+	filter Filter
+	fn     func(Change)
+}
+
+// Tracker keeps orders by symbol and order id, it's safe for concurrent use
+type Tracker struct {
+	// deliver is held across applying an update and notifying subscribers,
+	// so changes are delivered in the order they were applied
+	deliver  sync.Mutex
+	mu       sync.Mutex
+	orders   map[orderKey]*Order
+	byClient map[string]*Order
+	subs     map[int]*subscription
+	nextSub  int
+}
+
+// New creates an empty tracker
+func New() *Tracker {
+	return &Tracker{
+		orders:   make(map[orderKey]*Order),
+		byClient: make(map[string]*Order),
+		subs:     make(map[int]*subscription),
+	}
+}
+
+// Subscribe calls fn for every change of orders selected by the filter.
+// fn is called after the update is applied, from the goroutine applying it, and must not block.
+// Changes are delivered one at a time in the order they were applied, so fn must not apply updates itself
+func (t *Tracker) Subscribe(f Filter, fn func(Change)) (unsubscribe func()) {
+	t.mu.Lock()
+	id := t.nextSub
+	t.nextSub++
+	t.subs[id] = &subscription{filter: f, fn: fn}
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		delete(t.subs, id)
+		t.mu.Unlock()
+	}
+}
+
+// Order returns the order by symbol and order id
+func (t *Tracker) Order(symbol string, orderID uint64) (Order, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.orders[orderKey{symbol, orderID}]
+	if !ok {
+		return Order{}, false
+	}
+
+	return o.clone(), true
+}
+
+// ClientOrder returns the order by the client order id it was placed with
+func (t *Tracker) ClientOrder(clientOrderID string) (Order, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.byClient[clientOrderID]
+	if !ok {
+		return Order{}, false
+	}
+
+	return o.clone(), true
+}
+
+// Orders returns orders selected by the filter
+func (t *Tracker) Orders(f Filter) []Order {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var res []Order
+	for _, o := range t.orders {
+		if f.Match(o) {
+			res = append(res, o.clone())
+		}
+	}
+	for _, o := range t.byClient {
+		// orders which aren't acknowledged yet are indexed only by client order id
+		if o.OrderID == 0 && f.Match(o) {
+			res = append(res, o.clone())
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+
+		return res[i].OrderID < res[j].OrderID
+	})
+
+	return res
+}
+
+// Prune forgets orders which can't be filled anymore and weren't updated since before
+func (t *Tracker) Prune(before time.Time) int {
+	ms := binance.TimeToMs(before)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := 0
+	for key, o := range t.orders {
+		if !o.Open() && o.UpdateTime < ms {
+			delete(t.orders, key)
+			if t.byClient[o.ClientOrderID] == o {
+				delete(t.byClient, o.ClientOrderID)
+			}
+			n++
+		}
+	}
+
+	return n
+}
+
+// Forget stops tracking the order, e.g. the one which was registered but never placed
+func (t *Tracker) Forget(symbol, clientOrderID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.byClient[clientOrderID]
+	if !ok || o.Symbol != symbol {
+		return false
+	}
+	delete(t.byClient, clientOrderID)
+	if o.OrderID != 0 {
+		delete(t.orders, orderKey{o.Symbol, o.OrderID})
+	}
+
+	return true
+}
+
+// Track registers the order before it is sent, so it is known while the response is awaited.
+// The request must have the symbol and the client order id
+func (t *Tracker) Track(req *binance.OrderReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	if req.NewClientOrderID == "" {
+		return binance.ErrInvalidClientOrderID
+	}
+	u := update{
+		symbol:        req.Symbol,
+		clientOrderID: req.NewClientOrderID,
+		side:          req.Side,
+		typ:           req.Type,
+		timeInForce:   req.TimeInForce,
+		strategyID:    req.StrategyID,
+		strategyType:  req.StrategyType,
+	}
+	var err error
+	for _, p := range []struct {
+		dst *decimal.Decimal
+		s   string
+	}{{&u.price, req.Price}, {&u.stopPrice, req.StopPrice}, {&u.origQty, req.Quantity}, {&u.origQuoteQty, req.QuoteQuantity}} {
+		if p.s == "" {
+			continue
+		}
+		if *p.dst, err = binance.ParseDecimal([]byte(p.s)); err != nil {
+			return err
+		}
+	}
+	t.apply(u)
+
+	return nil
+}
+
+// ApplyOrderResult applies the RESULT response of the new order
+func (t *Tracker) ApplyOrderResult(r *binance.OrderRespResult) bool {
+	return t.apply(update{
+		symbol:        r.Symbol,
+		orderID:       r.OrderID,
+		orderListID:   int64(r.OrderListID),
+		clientOrderID: r.ClientOrderID,
+		side:          r.Side,
+		typ:           r.Type,
+		timeInForce:   binance.TimeInForce(r.TimeInForce),
+		price:         r.Price,
+		origQty:       r.OrigQty,
+		strategyID:    r.StrategyID,
+		strategyType:  r.StrategyType,
+		status:        r.Status,
+		executedQty:   r.ExecutedQty,
+		quoteQty:      r.CummulativeQuoteQty,
+		createTime:    r.TransactTime,
+		time:          r.TransactTime,
+	})
+}
+
+// ApplyOrderResp applies the FULL response of the new order including its fills
+func (t *Tracker) ApplyOrderResp(r *binance.OrderRespFull) bool {
+	u := update{
+		symbol:        r.Symbol,
+		orderID:       r.OrderID,
+		orderListID:   r.OrderListID,
+		clientOrderID: r.ClientOrderID,
+		side:          r.Side,
+		typ:           r.Type,
+		timeInForce:   binance.TimeInForce(r.TimeInForce),
+		price:         r.Price,
+		origQty:       r.OrigQty,
+		strategyID:    r.StrategyID,
+		strategyType:  r.StrategyType,
+		status:        r.Status,
+		executedQty:   r.ExecutedQty,
+		quoteQty:      r.CummulativeQuoteQty,
+		createTime:    r.TransactTime,
+		time:          r.TransactTime,
+	}
+	for i := range r.Fills {
+		f := &r.Fills[i]
+		u.fills = append(u.fills, Fill{
+			TradeID:         f.TradeID,
+			Price:           f.Price,
+			Qty:             f.Qty,
+			Commission:      f.Commission,
+			CommissionAsset: f.CommissionAsset,
+		})
+	}
+
+	return t.apply(u)
+}
+
+// ApplyQueryOrder applies the queried order state
+func (t *Tracker) ApplyQueryOrder(o *binance.QueryOrder) bool {
+	return t.apply(update{
+		symbol:        o.Symbol,
+		orderID:       o.OrderID,
+		orderListID:   o.OrderListID,
+		clientOrderID: o.ClientOrderID,
+		side:          o.Side,
+		typ:           o.Type,
+		timeInForce:   o.TimeInForce,
+		price:         o.Price,
+		stopPrice:     o.StopPrice,
+		origQty:       o.OrigQty,
+		origQuoteQty:  o.OrigQuoteOrderQty,
+		strategyID:    o.StrategyID,
+		strategyType:  o.StrategyType,
+		status:        o.Status,
+		executedQty:   o.ExecutedQty,
+		quoteQty:      o.CummulativeQuoteQty,
+		createTime:    o.Time,
+		time:          o.UpdateTime,
+	})
+}
+
+// ApplyCancelOrder applies the canceled order response
+func (t *Tracker) ApplyCancelOrder(o *binance.CancelOrder) bool {
+	return t.apply(update{
+		symbol:        o.Symbol,
+		orderID:       o.OrderID,
+		orderListID:   o.OrderListID,
+		clientOrderID: o.OrigClientOrderID,
+		side:          o.Side,
+		typ:           o.Type,
+		timeInForce:   o.TimeInForce,
+		price:         o.Price,
+		origQty:       o.OrigQty,
+		strategyID:    o.StrategyID,
+		strategyType:  o.StrategyType,
+		status:        o.Status,
+		executedQty:   o.ExecutedQty,
+		quoteQty:      o.CummulativeQuoteQty,
+		time:          binance.TimeToMs(time.Now()), // the response has no transaction time
+	})
+}
+
+// ApplyOrderUpdate applies the execution report of the user data stream
+func (t *Tracker) ApplyOrderUpdate(e *ws.OrderUpdateEvent) bool {
+	u := update{
+		symbol:        e.Symbol,
+		orderID:       e.OrderID,
+		orderListID:   e.OrderListID,
+		clientOrderID: e.NewClientOrderID,
+		side:          e.Side,
+		typ:           e.OrderType,
+		timeInForce:   e.TimeInForce,
+		price:         e.Price,
+		stopPrice:     e.StopPrice,
+		origQty:       e.OrigQty,
+		origQuoteQty:  e.QuoteQty,
+		strategyID:    e.StrategyID,
+		strategyType:  e.StrategyType,
+		status:        e.Status,
+		rejectReason:  e.Error,
+		executedQty:   e.TotalFilledQty,
+		quoteQty:      e.QuoteTotalFilledQty,
+		createTime:    e.OrderCreatedTime,
+		time:          e.TradeTime,
+	}
+	// canceled and amended orders report the id of the request in c and the previous id in C
+	if e.OrigClientOrderID != "" {
+		u.clientOrderID = e.OrigClientOrderID
+	}
+	if u.time == 0 {
+		u.time = e.Time
+	}
+	if e.ExecutionType == binance.OrderStatusTrade {
+		u.fills = []Fill{{
+			TradeID:         e.TradeID,
+			Price:           e.FilledPrice,
+			Qty:             e.FilledQty,
+			Commission:      e.Commission,
+			CommissionAsset: e.CommissionAsset,
+			Time:            e.TradeTime,
+			Maker:           e.Maker,
+		}}
+	}
+
+	return t.apply(u)
+}
+
+// update is the order state reported by any of the sources
+type update struct {
+	symbol        string
+	orderID       uint64
+	orderListID   int64
+	clientOrderID string
+	side          binance.OrderSide
+	typ           binance.OrderType
+	timeInForce   binance.TimeInForce
+	price         decimal.Decimal
+	stopPrice     decimal.Decimal
+	origQty       decimal.Decimal
+	origQuoteQty  decimal.Decimal
+	strategyID    int
+	strategyType  int
+
+	status       binance.OrderStatus
+	rejectReason binance.OrderFailure
+	executedQty  decimal.Decimal
+	quoteQty     decimal.Decimal
+	fills        []Fill
+	createTime   uint64
+	time         uint64
+}
+
+// apply merges the update into the tracked order and notifies subscribers, it reports whether the order changed
+func (t *Tracker) apply(u update) bool {
+	t.deliver.Lock()
+	defer t.deliver.Unlock()
+	t.mu.Lock()
+	o, created := t.lookup(u)
+	var prev *Order
+	if !created {
+		p := o.clone()
+		prev = &p
+	}
+	added := o.addFills(u.fills)
+	changed := o.advance(u) || created || added > 0
+	if o.ClientOrderID != "" && t.byClient[o.ClientOrderID] == nil {
+		t.byClient[o.ClientOrderID] = o
+	}
+	var notify []func(Change)
+	var change Change
+	if changed {
+		change = Change{Prev: prev, Order: o.clone()}
+		if added > 0 {
+			change.Fills = change.Order.Fills[len(change.Order.Fills)-added:]
+		}
+		for _, s := range t.subs {
+			if s.filter.Match(o) {
+				notify = append(notify, s.fn)
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	for _, fn := range notify {
+		fn(change)
+	}
+
+	return changed
+}
+
+// lookup returns the tracked order of the update creating it if needed
+func (t *Tracker) lookup(u update) (*Order, bool) {
+	if u.orderID != 0 {
+		if o, ok := t.orders[orderKey{u.symbol, u.orderID}]; ok {
+			return o, false
+		}
+	}
+	if u.clientOrderID != "" {
+		// the order tracked before it was acknowledged
+		if o, ok := t.byClient[u.clientOrderID]; ok && o.Symbol == u.symbol && (o.OrderID == 0 || o.OrderID == u.orderID) {
+			if o.OrderID == 0 && u.orderID != 0 {
+				o.OrderID = u.orderID
+				t.orders[orderKey{o.Symbol, o.OrderID}] = o
+			}
+
+			return o, false
+		}
+	}
+	o := &Order{
+		Symbol:        u.symbol,
+		OrderID:       u.orderID,
+		ClientOrderID: u.clientOrderID,
+		Commissions:   make(map[string]decimal.Decimal),
+	}
+	if o.OrderID != 0 {
+		t.orders[orderKey{o.Symbol, o.OrderID}] = o
+	}
+	if o.ClientOrderID != "" {
+		t.byClient[o.ClientOrderID] = o
+	}
+
+	return o, true
+}
+
+// addFills appends fills which aren't known yet and returns the number of added fills
+func (o *Order) addFills(fills []Fill) int {
+	added := 0
+	for i := range fills {
+		f := fills[i]
+		if o.hasFill(f.TradeID) {
+			continue
+		}
+		o.Fills = append(o.Fills, f)
+		if !f.Commission.IsZero() {
+			o.Commissions[f.CommissionAsset] = o.Commissions[f.CommissionAsset].Add(f.Commission)
+		}
+		added++
+	}
+
+	return added
+}
+
+func (o *Order) hasFill(tradeID int64) bool {
+	for i := range o.Fills {
+		if o.Fills[i].TradeID == tradeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// advance applies the order state of the update unless it is older than the current one
+func (o *Order) advance(u update) bool {
+	if u.status == "" && o.Status != "" {
+		return o.describe(u)
+	}
+	switch cmp := u.executedQty.Cmp(o.ExecutedQty); {
+	case rank(o.Status) == rankFinal, cmp < 0:
+		return o.describe(u)
+	case cmp == 0 && rank(u.status) < rank(o.Status):
+		return o.describe(u)
+	case cmp == 0 && u.status == o.Status && u.time != 0 && u.time < o.UpdateTime:
+		return o.describe(u)
+	}
+	changed := o.describe(u)
+	if u.status != "" && u.status != o.Status {
+		o.Status = u.status
+		changed = true
+	}
+	if u.rejectReason != "" && u.rejectReason != binance.OrderFailureNone && u.rejectReason != o.RejectReason {
+		o.RejectReason = u.rejectReason
+		changed = true
+	}
+	// amended orders have lower original quantity
+	if !u.origQty.IsZero() && !u.origQty.Equal(o.OrigQty) {
+		o.OrigQty = u.origQty
+		changed = true
+	}
+	if !u.executedQty.Equal(o.ExecutedQty) || !u.quoteQty.Equal(o.CummulativeQuoteQty) {
+		o.ExecutedQty = u.executedQty
+		o.CummulativeQuoteQty = u.quoteQty
+		changed = true
+	}
+	if u.time > o.UpdateTime {
+		o.UpdateTime = u.time
+		changed = true
+	}
+
+	return changed
+}
+
+// describe fills order parameters unknown yet, they don't change during the order life
+func (o *Order) describe(u update) bool {
+	changed := false
+	setString := func(dst *string, v string) {
+		if *dst == "" && v != "" {
+			*dst = v
+			changed = true
+		}
+	}
+	setDecimal := func(dst *decimal.Decimal, v decimal.Decimal) {
+		if dst.IsZero() && !v.IsZero() {
+			*dst = v
+			changed = true
+		}
+	}
+	setString(&o.ClientOrderID, u.clientOrderID)
+	setString((*string)(&o.Side), string(u.side))
+	setString((*string)(&o.Type), string(u.typ))
+	setString((*string)(&o.TimeInForce), string(u.timeInForce))
+	setDecimal(&o.Price, u.price)
+	setDecimal(&o.StopPrice, u.stopPrice)
+	setDecimal(&o.OrigQty, u.origQty)
+	setDecimal(&o.OrigQuoteQty, u.origQuoteQty)
+	if o.OrderListID == 0 && u.orderListID != 0 {
+		o.OrderListID = u.orderListID
+		changed = true
+	}
+	if o.StrategyID == 0 && u.strategyID != 0 {
+		o.StrategyID, o.StrategyType = u.strategyID, u.strategyType
+		changed = true
+	}
+	if o.CreateTime == 0 && u.createTime != 0 {
+		o.CreateTime = u.createTime
+		changed = true
+	}
+
+	return changed
+}
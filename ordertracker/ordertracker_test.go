@@ -0,0 +1,251 @@
+package ordertracker_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/ordertracker"
+	"github.com/ugi1/binance-api/ws"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func tradeEvent(tradeID int64, status binance.OrderStatus, qty, total, price string, ms uint64) *ws.OrderUpdateEvent {
+	return &ws.OrderUpdateEvent{
+		Symbol:              "BTCUSDT",
+		OrderID:             10,
+		NewClientOrderID:    "client",
+		Side:                binance.OrderSideBuy,
+		OrderType:           binance.OrderTypeLimit,
+		ExecutionType:       binance.OrderStatusTrade,
+		Status:              status,
+		OrigQty:             dec("2"),
+		Price:               dec("100"),
+		FilledQty:           dec(qty),
+		FilledPrice:         dec(price),
+		TotalFilledQty:      dec(total),
+		QuoteTotalFilledQty: dec(total).Mul(dec(price)),
+		Commission:          dec("0.001"),
+		CommissionAsset:     "BNB",
+		TradeID:             tradeID,
+		TradeTime:           ms,
+		OrderListID:         -1,
+	}
+}
+
+func TestTrackerLifecycle(t *testing.T) {
+	tr := ordertracker.New()
+	var changes []ordertracker.Change
+	unsubscribe := tr.Subscribe(ordertracker.Filter{Symbol: "BTCUSDT"}, func(c ordertracker.Change) {
+		changes = append(changes, c)
+	})
+	tr.Subscribe(ordertracker.Filter{Symbol: "ETHUSDT"}, func(c ordertracker.Change) {
+		t.Fatal("unexpected change", c.Order.Symbol)
+	})
+
+	req := binance.NewLimitOrderReq("BTCUSDT", binance.OrderSideBuy, "", dec("2"), dec("100")).WithClientOrderID("client")
+	require.NoError(t, tr.Track(req))
+	o, ok := tr.ClientOrder("client")
+	require.True(t, ok)
+	require.True(t, o.Open())
+	require.Empty(t, o.Status)
+	require.Len(t, tr.Orders(ordertracker.Filter{Open: true}), 1)
+
+	// the trade event arrives before the order response
+	require.True(t, tr.ApplyOrderUpdate(tradeEvent(1, binance.OrderStatusPartial, "1", "1", "100", 1000)))
+	require.True(t, tr.ApplyOrderResp(&binance.OrderRespFull{
+		Symbol:              "BTCUSDT",
+		OrderID:             10,
+		OrderListID:         -1,
+		ClientOrderID:       "client",
+		TransactTime:        1000,
+		Price:               dec("100"),
+		OrigQty:             dec("2"),
+		ExecutedQty:         dec("0"),
+		Status:              binance.OrderStatusNew,
+		Type:                binance.OrderTypeLimit,
+		Side:                binance.OrderSideBuy,
+		TimeInForce:         "GTC",
+		Fills:               nil,
+		CummulativeQuoteQty: dec("0"),
+	}))
+	o, ok = tr.Order("BTCUSDT", 10)
+	require.True(t, ok)
+	require.Equal(t, binance.OrderStatusPartial, o.Status)
+	require.Equal(t, binance.TimeInForceGTC, o.TimeInForce)
+	require.Equal(t, "1", o.ExecutedQty.String())
+
+	// duplicated events don't change the order
+	require.False(t, tr.ApplyOrderUpdate(tradeEvent(1, binance.OrderStatusPartial, "1", "1", "100", 1000)))
+
+	require.True(t, tr.ApplyOrderUpdate(tradeEvent(2, binance.OrderStatusFilled, "1", "2", "99", 1100)))
+	// stale query doesn't move the order back
+	require.False(t, tr.ApplyQueryOrder(&binance.QueryOrder{
+		Symbol:      "BTCUSDT",
+		OrderID:     10,
+		Status:      binance.OrderStatusPartial,
+		ExecutedQty: dec("1"),
+		UpdateTime:  1000,
+	}))
+
+	o, ok = tr.ClientOrder("client")
+	require.True(t, ok)
+	require.False(t, o.Open())
+	require.Equal(t, binance.OrderStatusFilled, o.Status)
+	require.Equal(t, "2", o.ExecutedQty.String())
+	require.Len(t, o.Fills, 2)
+	require.Equal(t, "0.002", o.Commissions["BNB"].String())
+	require.Equal(t, "99", o.AvgPrice().String())
+	require.Empty(t, tr.Orders(ordertracker.Filter{Open: true}))
+
+	require.Len(t, changes, 4)
+	require.Nil(t, changes[0].Prev)
+	require.Len(t, changes[1].Fills, 1)
+	require.EqualValues(t, 1, changes[1].Fills[0].TradeID)
+	require.Empty(t, changes[2].Fills)
+	require.Equal(t, binance.OrderStatusPartial, changes[3].Prev.Status)
+	require.Len(t, changes[3].Fills, 1)
+	require.EqualValues(t, 2, changes[3].Fills[0].TradeID)
+
+	unsubscribe()
+	require.True(t, tr.ApplyCancelOrder(&binance.CancelOrder{
+		Symbol:      "BTCUSDT",
+		OrderID:     11,
+		Status:      binance.OrderStatusCanceled,
+		ExecutedQty: dec("0"),
+	}))
+	require.Len(t, changes, 4)
+
+	require.Equal(t, 0, tr.Prune(binance.MsToTime(1100)))
+	require.Equal(t, 2, tr.Prune(time.Now().Add(time.Second)))
+	require.Empty(t, tr.Orders(ordertracker.Filter{}))
+}
+
+func TestTrackerFullResponseFills(t *testing.T) {
+	tr := ordertracker.New()
+	var changes []ordertracker.Change
+	tr.Subscribe(ordertracker.Filter{}, func(c ordertracker.Change) {
+		changes = append(changes, c)
+	})
+
+	require.True(t, tr.ApplyOrderUpdate(tradeEvent(1, binance.OrderStatusPartial, "0.5", "0.5", "100", 1000)))
+	require.True(t, tr.ApplyOrderResp(&binance.OrderRespFull{
+		Symbol:              "BTCUSDT",
+		OrderID:             10,
+		OrderListID:         -1,
+		ClientOrderID:       "client",
+		TransactTime:        1000,
+		Price:               dec("100"),
+		OrigQty:             dec("2"),
+		ExecutedQty:         dec("2"),
+		CummulativeQuoteQty: dec("199"),
+		Status:              binance.OrderStatusFilled,
+		Type:                binance.OrderTypeLimit,
+		Side:                binance.OrderSideBuy,
+		Fills: []binance.OrderRespFullFill{
+			{TradeID: 1, Price: dec("100"), Qty: dec("0.5"), Commission: dec("0.001"), CommissionAsset: "BNB"},
+			{TradeID: 2, Price: dec("99"), Qty: dec("1"), Commission: dec("0.001"), CommissionAsset: "BNB"},
+			{TradeID: 3, Price: dec("100"), Qty: dec("0.5"), Commission: dec("0.001"), CommissionAsset: "BNB"},
+		},
+	}))
+
+	// the response adds every fill which wasn't known yet in a single change
+	require.Len(t, changes, 2)
+	require.Len(t, changes[1].Fills, 2)
+	require.EqualValues(t, 2, changes[1].Fills[0].TradeID)
+	require.EqualValues(t, 3, changes[1].Fills[1].TradeID)
+	require.Len(t, changes[1].Order.Fills, 3)
+	require.Equal(t, "0.003", changes[1].Order.Commissions["BNB"].String())
+}
+
+func TestTrackerConcurrentDelivery(t *testing.T) {
+	const trades = 50
+	tr := ordertracker.New()
+	var last *ordertracker.Order
+	delivered := 0
+	tr.Subscribe(ordertracker.Filter{}, func(c ordertracker.Change) {
+		// every change follows the previously delivered one
+		if last == nil {
+			require.Nil(t, c.Prev)
+		} else {
+			require.NotNil(t, c.Prev)
+			require.Len(t, c.Prev.Fills, len(last.Fills))
+			require.True(t, c.Prev.ExecutedQty.Equal(last.ExecutedQty))
+			require.Equal(t, last.Status, c.Prev.Status)
+			require.False(t, c.Order.ExecutedQty.LessThan(last.ExecutedQty))
+		}
+		o := c.Order
+		last = &o
+		delivered++
+	})
+
+	var wg sync.WaitGroup
+	for i := 1; i <= trades; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			status := binance.OrderStatusPartial
+			if i == trades {
+				status = binance.OrderStatusFilled
+			}
+			e := tradeEvent(int64(i), status, "1", strconv.Itoa(i), "100", uint64(1000+i))
+			e.OrigQty = dec(strconv.Itoa(trades))
+			tr.ApplyOrderUpdate(e)
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, trades, delivered)
+	require.Equal(t, binance.OrderStatusFilled, last.Status)
+	require.Len(t, last.Fills, trades)
+}
+
+func TestTrackerAmendAndFilters(t *testing.T) {
+	gen, err := binance.NewClientOrderIDGenerator("bot", "grid")
+	require.NoError(t, err)
+	id, err := gen.Next()
+	require.NoError(t, err)
+
+	tr := ordertracker.New()
+	require.True(t, tr.ApplyOrderResult(&binance.OrderRespResult{
+		Symbol:        "ETHUSDT",
+		OrderID:       5,
+		ClientOrderID: id,
+		TransactTime:  1000,
+		OrigQty:       dec("3"),
+		ExecutedQty:   dec("0"),
+		Status:        binance.OrderStatusNew,
+		StrategyID:    7,
+		StrategyType:  1000000,
+	}))
+	require.True(t, tr.ApplyOrderUpdate(&ws.OrderUpdateEvent{
+		Symbol:            "ETHUSDT",
+		OrderID:           5,
+		NewClientOrderID:  "amend",
+		OrigClientOrderID: id,
+		ExecutionType:     binance.OrderStatusReplaced,
+		Status:            binance.OrderStatusNew,
+		OrigQty:           dec("2"),
+		TotalFilledQty:    dec("0"),
+		TradeTime:         1200,
+	}))
+	o, ok := tr.ClientOrder(id)
+	require.True(t, ok)
+	require.Equal(t, "2", o.OrigQty.String())
+	require.EqualValues(t, 1200, o.UpdateTime)
+
+	require.Len(t, tr.Orders(ordertracker.Filter{Tag: "grid"}), 1)
+	require.Empty(t, tr.Orders(ordertracker.Filter{Tag: "other"}))
+	require.Len(t, tr.Orders(ordertracker.Filter{StrategyID: 7, Open: true}), 1)
+	require.Empty(t, tr.Orders(ordertracker.Filter{Symbol: "BTCUSDT"}))
+
+	require.ErrorIs(t, tr.Track(binance.NewMarketOrderReq("ETHUSDT", binance.OrderSideBuy, dec("1"))), binance.ErrInvalidClientOrderID)
+}
@@ -0,0 +1,64 @@
+package binance
+
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+const (
+	// DefaultPacerWeightLimit is the share of 6000 per minute request weight paginated helpers are allowed to use
+	DefaultPacerWeightLimit = 4800
+	// DefaultPacerRetries is how many times a request is retried after too many requests error
+	DefaultPacerRetries = 3
+)
+
+// Pacer throttles paginated requests to stay within the request weight limit.
+// Before every request it checks X-MBX-USED-WEIGHT-1M reported by the last response and pauses
+// until the next minute once WeightLimit is reached. Requests rejected with too many requests error
+// are retried after the Retry-After period.
+type Pacer struct {
+	WeightLimit int64 // WeightLimit is the used weight per minute after which requests are paused, zero disables the check
+	MaxRetries  int   // MaxRetries is the number of retries after too many requests error
+}
+
+// NewPacer creates a pacer with default limits
+func NewPacer() *Pacer {
+	return &Pacer{
+		WeightLimit: DefaultPacerWeightLimit,
+		MaxRetries:  DefaultPacerRetries,
+	}
+}
+
+// Do calls fn when request weight allows it and retries on too many requests error
+func (p *Pacer) Do(c RestClient, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		p.wait(c)
+		err := fn()
+		if err == nil || attempt >= p.MaxRetries || !isTooManyRequests(err) {
+			return err
+		}
+		retry := time.Duration(c.RetryAfter()) * time.Second
+		if retry <= 0 {
+			retry = time.Second
+		}
+		time.Sleep(retry)
+	}
+}
+
+func (p *Pacer) wait(c RestClient) {
+	if p.WeightLimit <= 0 {
+		return
+	}
+	if c.UsedWeight()["1m"] < p.WeightLimit {
+		return
+	}
+	now := time.Now()
+	time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+}
+
+func isTooManyRequests(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeTooManyRequests
+}
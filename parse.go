@@ -0,0 +1,233 @@
+package binance
+
+import (
+	"bytes"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+// arrayParser reads elements of a JSON array in a single pass without allocations.
+// Elements are strings or numbers, whitespace and extra trailing elements of any type are tolerated.
+// It keeps the first error, which holds the element index and its byte offset, so fields can be read without checks
+type arrayParser struct {
+	name  string
+	data  []byte
+	pos   int
+	start int
+	index int
+	err   error
+}
+
+func newArrayParser(name string, data []byte) arrayParser {
+	p := arrayParser{name: name, data: data, index: -1}
+	p.skipSpace()
+	if p.pos >= len(p.data) || p.data[p.pos] != '[' {
+		p.fail("expected array")
+
+		return p
+	}
+	p.pos++
+
+	return p
+}
+
+func (p *arrayParser) fail(msg string) {
+	if p.err == nil {
+		p.err = errors.Wrapf(ErrInvalidJSON, "%s element %d at offset %d: %s", p.name, p.index, p.start, msg)
+	}
+}
+
+func (p *arrayParser) failErr(err error) {
+	if p.err == nil {
+		p.err = errors.Wrapf(ErrInvalidJSON, "%s element %d at offset %d: %v", p.name, p.index, p.start, err)
+	}
+}
+
+func (p *arrayParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// delim moves to the next element, it returns false at the end of the array
+func (p *arrayParser) delim() bool {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		p.fail("unexpected end of input")
+
+		return false
+	}
+	c := p.data[p.pos]
+	if c == ']' {
+		return false
+	}
+	if p.index >= 0 {
+		if c != ',' {
+			p.fail("expected ',' or ']'")
+
+			return false
+		}
+		p.pos++
+		p.skipSpace()
+	}
+	p.index++
+	p.start = p.pos
+
+	return true
+}
+
+// next returns the next element, strings are returned without quotes
+func (p *arrayParser) next() []byte {
+	if p.err != nil {
+		return nil
+	}
+	if !p.delim() {
+		p.fail("too few elements")
+
+		return nil
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '"' {
+		start := p.pos + 1
+		for i := start; i < len(p.data); i++ {
+			switch p.data[i] {
+			case '"':
+				p.pos = i + 1
+
+				return p.data[start:i]
+			case '\\':
+				p.fail("unexpected escape sequence")
+
+				return nil
+			}
+		}
+		p.fail("unterminated string")
+
+		return nil
+	}
+	start := p.pos
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ',', ']', ' ', '\t', '\n', '\r':
+			return p.data[start:p.pos]
+		case '"', '[', '{', '}':
+			p.fail("unexpected character")
+
+			return nil
+		}
+		p.pos++
+	}
+
+	return p.data[start:p.pos]
+}
+
+func (p *arrayParser) uint() uint64 {
+	b := p.next()
+	if p.err != nil {
+		return 0
+	}
+	if len(b) == 0 || len(b) > 20 {
+		p.fail("invalid integer")
+
+		return 0
+	}
+	var v uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			p.fail("invalid integer")
+
+			return 0
+		}
+		n := v*10 + uint64(c-'0')
+		if n/10 != v {
+			p.fail("integer overflow")
+
+			return 0
+		}
+		v = n
+	}
+
+	return v
+}
+
+func (p *arrayParser) decimal() decimal.Decimal {
+	b := p.next()
+	if p.err != nil {
+		return decimal.Decimal{}
+	}
+	d, err := ParseDecimal(b)
+	if err != nil {
+		p.failErr(err)
+	}
+
+	return d
+}
+
+// finish skips extra elements and checks that nothing follows the array
+func (p *arrayParser) finish() error {
+	for p.err == nil && p.delim() {
+		start := p.pos
+		p.skipValue()
+		if p.pos == start {
+			p.fail("missing value")
+		}
+	}
+	if p.err != nil {
+		return p.err
+	}
+	p.pos++
+	p.skipSpace()
+	if p.pos < len(p.data) {
+		p.fail("unexpected data after array")
+	}
+
+	return p.err
+}
+
+// skipValue skips any JSON value including nested arrays and objects
+func (p *arrayParser) skipValue() {
+	depth := 0
+	for ; p.pos < len(p.data); p.pos++ {
+		switch c := p.data[p.pos]; c {
+		case '"':
+			p.pos++
+			for p.pos < len(p.data) && p.data[p.pos] != '"' {
+				if p.data[p.pos] == '\\' {
+					p.pos++
+				}
+				p.pos++
+			}
+			if p.pos >= len(p.data) {
+				p.fail("unterminated string")
+
+				return
+			}
+		case '[', '{':
+			depth++
+		case ']', '}':
+			if depth == 0 {
+				return
+			}
+			depth--
+		case ',', ' ', '\t', '\n', '\r':
+			if depth == 0 {
+				return
+			}
+		}
+	}
+	if depth > 0 {
+		p.fail("unexpected end of input")
+	}
+}
+
+// isNull reports whether data is JSON null, which leaves the value unchanged
+func isNull(data []byte) bool {
+	data = bytes.TrimSpace(data)
+
+	return len(data) == 0 || b2s(data) == "null"
+}
@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package binance_test
+
+import (
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+
+	"github.com/ugi1/binance-api"
+)
+
+func FuzzKlinesUnmarshalJSON(f *testing.F) {
+	f.Add([]byte(testKlinesJSON))
+	f.Add([]byte(` [1499040000000, "0.1", "0.2", "0.3", "0.4", "5", 1499644799999, "6", 7, "8", "9", [{"x":"]"}]] `))
+	f.Add([]byte(`[1,"1","1","1","1","1",1,"1",1,"1"]`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		k := &binance.Klines{}
+		if err := k.UnmarshalJSON(data); err != nil {
+			return
+		}
+		// successfully parsed klines must survive the round trip unchanged
+		encoded, err := json.Marshal(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &binance.Klines{}
+		if err = decoded.UnmarshalJSON(encoded); err != nil {
+			t.Fatalf("%s: %v", encoded, err)
+		}
+		again, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != string(encoded) {
+			t.Fatalf("round trip mismatch: %s != %s", again, encoded)
+		}
+	})
+}
+
+func FuzzDepthElemUnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`["4.00000200","12.00000000"]`))
+	f.Add([]byte(` [ 4.2 , "1e3" , null ] `))
+	f.Add([]byte(`["4.0\"","1"]`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		elem := &binance.DepthElem{}
+		if err := elem.UnmarshalJSON(data); err != nil {
+			return
+		}
+		encoded, err := json.Marshal(elem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &binance.DepthElem{}
+		if err = decoded.UnmarshalJSON(encoded); err != nil {
+			t.Fatalf("%s: %v", encoded, err)
+		}
+		if !decoded.Price.Equal(elem.Price) || !decoded.Quantity.Equal(elem.Quantity) {
+			t.Fatalf("round trip mismatch: %s", encoded)
+		}
+	})
+}
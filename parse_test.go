@@ -0,0 +1,205 @@
+package binance_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/ugi1/binance-api"
+)
+
+func TestKlinesUnmarshalJSON(t *testing.T) {
+	for name, raw := range map[string]string{
+		"compact":   testKlinesJSON,
+		"spaces":    " [ 1499040000000 , \"0.01634790\",\"0.80000000\",\n\"0.01575800\",\"0.01577100\",\"148976.11427815\",\t1499644799999,\"2434.19055334\",308,\"1756.87402397\",\"28.46694368\" ] ",
+		"no ignore": `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368"]`,
+		"extra":     `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0",null,{"a":["\"]"]},[1,[2]]]`,
+		"quoted":    `["1499040000000","0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815","1499644799999","2434.19055334","308","1756.87402397","28.46694368","0"]`,
+	} {
+		k := &binance.Klines{}
+		require.NoError(t, json.Unmarshal([]byte(raw), k), name)
+		data, err := json.Marshal(k)
+		require.NoError(t, err, name)
+		require.JSONEq(t, testKlinesJSON, string(data), name)
+	}
+
+	for name, raw := range map[string]string{
+		"empty array":      `[]`,
+		"too few":          `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397"]`,
+		"bad time":         `[-1,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368"]`,
+		"bad decimal":      `[1499040000000,"0.016.34790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368"]`,
+		"missing comma":    `[1499040000000 "0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368"]`,
+		"missing value":    `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368",,]`,
+		"unterminated":     `[1499040000000,"0.01634790`,
+		"object":           `{"openTime":1499040000000}`,
+		"overflow":         `[99999999999999999999,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368"]`,
+		"trailing garbage": `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368"]x`,
+	} {
+		k := &binance.Klines{}
+		err := k.UnmarshalJSON([]byte(raw))
+		require.ErrorIs(t, err, binance.ErrInvalidJSON, name)
+		require.Zero(t, k.OpenTime, name)
+	}
+
+	err := (&binance.Klines{}).UnmarshalJSON([]byte(`[1499040000000,"0.01634790","0.80000000","0.01575800","x"]`))
+	require.ErrorIs(t, err, binance.ErrInvalidJSON)
+	require.Contains(t, err.Error(), "klines element 4 at offset 54")
+
+	k := &binance.Klines{OpenTime: 1}
+	require.NoError(t, k.UnmarshalJSON([]byte("null")))
+	require.EqualValues(t, 1, k.OpenTime)
+}
+
+func TestDepthElemUnmarshalJSON(t *testing.T) {
+	for name, raw := range map[string]string{
+		"compact": `["4.00000200","12.00000000"]`,
+		"spaces":  ` [ "4.00000200" ,	"12.00000000" ] `,
+		"extra":   `["4.00000200","12.00000000",[]]`,
+		"numbers": `[4.00000200,12.00000000]`,
+	} {
+		elem := &binance.DepthElem{}
+		require.NoError(t, json.Unmarshal([]byte(raw), elem), name)
+		require.Equal(t, "4.00000200", binance.FormatDecimal(elem.Price), name)
+		require.Equal(t, "12.00000000", binance.FormatDecimal(elem.Quantity), name)
+	}
+
+	for name, raw := range map[string]string{
+		"empty array":  `[]`,
+		"single":       `["4.00000200"]`,
+		"empty string": `["","12.00000000"]`,
+		"escape":       `["4.0\u0030","12.00000000"]`,
+		"unterminated": `["4.00000200","12.00000000"`,
+		"not array":    `"4.00000200"`,
+		"nested":       `[["4.00000200"],"12.00000000"]`,
+	} {
+		err := (&binance.DepthElem{}).UnmarshalJSON([]byte(raw))
+		require.ErrorIs(t, err, binance.ErrInvalidJSON, name)
+	}
+}
+
+// legacyKlinesUnmarshal is the previous implementation kept for benchmarks
+func legacyKlinesUnmarshal(b *binance.Klines, data []byte) error {
+	s := bytes.ReplaceAll(data, []byte(`"`), nil)
+	tokens := bytes.Split(s, []byte(`,`))
+	if len(tokens) < 11 {
+		return binance.ErrInvalidJSON
+	}
+	u, err := fasthttp.ParseUint(tokens[0][1:])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.OpenTime = uint64(u)
+	b.OpenPrice, err = binance.ParseDecimal(tokens[1])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.High, err = binance.ParseDecimal(tokens[2])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.Low, err = binance.ParseDecimal(tokens[3])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.ClosePrice, err = binance.ParseDecimal(tokens[4])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.Volume, err = binance.ParseDecimal(tokens[5])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	u, err = fasthttp.ParseUint(tokens[6])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.CloseTime = uint64(u)
+	b.QuoteAssetVolume, err = binance.ParseDecimal(tokens[7])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.Trades, err = fasthttp.ParseUint(tokens[8])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.TakerBuyBaseAssetVolume, err = binance.ParseDecimal(tokens[9])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+	b.TakerBuyQuoteAssetVolume, err = binance.ParseDecimal(tokens[10])
+	if err != nil {
+		return binance.ErrInvalidJSON
+	}
+
+	return nil
+}
+
+// legacyDepthElemUnmarshal is the previous implementation kept for benchmarks
+func legacyDepthElemUnmarshal(b *binance.DepthElem, data []byte) error {
+	if len(data) <= 4 {
+		return nil
+	}
+	qty, price := 3, 0
+	next := false
+	for qty < len(data)-1 {
+		if data[qty] == '"' {
+			if next {
+				break
+			}
+			next = true
+			price = qty
+			qty += 3
+
+			continue
+		}
+		qty++
+	}
+	if price < 3 || qty < 4 || !next {
+		return binance.ErrInvalidJSON
+	}
+	var err error
+	b.Price, err = binance.ParseDecimal(data[2:price])
+	if err != nil {
+		return err
+	}
+	b.Quantity, err = binance.ParseDecimal(data[price+3 : qty])
+
+	return err
+}
+
+func BenchmarkKlinesUnmarshalJSON(b *testing.B) {
+	data := []byte(testKlinesJSON)
+	k := &binance.Klines{}
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = k.UnmarshalJSON(data)
+		}
+	})
+	b.Run("Legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = legacyKlinesUnmarshal(k, data)
+		}
+	})
+}
+
+func BenchmarkDepthElemUnmarshalJSON(b *testing.B) {
+	data := []byte(`["4.00000200","12.00000000"]`)
+	elem := &binance.DepthElem{}
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = elem.UnmarshalJSON(data)
+		}
+	})
+	b.Run("Legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = legacyDepthElemUnmarshal(elem, data)
+		}
+	})
+}
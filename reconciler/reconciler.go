@@ -0,0 +1,340 @@
+// Package reconciler periodically compares orders of ordertracker.Tracker with the exchange.
+//
+// Missed user data stream events leave tracked orders stale. Every pass requests open orders of each
+// symbol and resolves the difference:
+//   - orphan orders are open on the exchange but aren't tracked, they start to be tracked
+//   - phantom orders are open locally but not on the exchange, their final state is queried
+//   - divergent orders are open on both but the exchange state is ahead, it is applied
+//
+// Passes are budgeted by request weight, symbols which don't fit the budget are reconciled first next time.
+//
+//	r := reconciler.New(client, tracker)
+//	r.OnReport = func(rep *reconciler.Report, err error) {
+//		for _, d := range rep.Discrepancies {
+//			log.Println(d.Kind, d.Symbol, d.OrderID)
+//		}
+//	}
+//	go r.Run(ctx)
+package reconciler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/ordertracker"
+)
+
+// Request weights of the used endpoints
+const (
+	WeightOpenOrders = 6 // WeightOpenOrders is the weight of open orders of a single symbol
+	WeightQueryOrder = 4
+	WeightAllOrders  = 20
+)
+
+const (
+	// DefaultInterval is the pause between passes
+	DefaultInterval = time.Minute
+	// DefaultWeightPerMinute is the share of 6000 per minute request weight passes are allowed to use
+	DefaultWeightPerMinute = 600
+	// DefaultGrace is how long orders registered before placement are expected to be acknowledged
+	DefaultGrace = 10 * time.Second
+)
+
+// Kind represents the type of discrepancy
+type Kind string
+
+const (
+	KindOrphan    Kind = "ORPHAN"    // KindOrphan order is open on the exchange but isn't tracked
+	KindPhantom   Kind = "PHANTOM"   // KindPhantom order is open locally but isn't open on the exchange
+	KindDivergent Kind = "DIVERGENT" // KindDivergent order is open on both but the local state is stale
+)
+
+// Discrepancy is a single order which differs from the exchange, it's already repaired when reported
+type Discrepancy struct {
+	Kind          Kind
+	Symbol        string
+	OrderID       uint64
+	ClientOrderID string
+	Local         *ordertracker.Order // Local is the state before repair, nil for orphan orders
+	Exchange      *binance.QueryOrder // Exchange is nil when the order doesn't exist on the exchange
+}
+
+// Report is the result of a single pass
+type Report struct {
+	Symbols       []string // Symbols are reconciled symbols
+	Deferred      []string // Deferred are symbols left for the next pass because of the weight budget
+	Discrepancies []Discrepancy
+	Weight        int64 // Weight is the request weight spent
+}
+
+// Reconciler repairs tracked orders using REST requests, it isn't safe for concurrent passes
+type Reconciler struct {
+	Symbols         []string      // Symbols are reconciled even without tracked open orders
+	Interval        time.Duration // Interval is the minimal pause between passes
+	WeightPerMinute int64         // WeightPerMinute limits the average weight spent by passes
+	Grace           time.Duration // Grace is how long orders registered before placement may stay unacknowledged
+	// OnReport is called after every pass of Run
+	OnReport func(*Report, error)
+
+	client   *binance.Client
+	tracker  *ordertracker.Tracker
+	deferred []string
+	pending  map[string]time.Time
+}
+
+// New creates a reconciler with default limits
+func New(client *binance.Client, tracker *ordertracker.Tracker) *Reconciler {
+	return &Reconciler{
+		Interval:        DefaultInterval,
+		WeightPerMinute: DefaultWeightPerMinute,
+		Grace:           DefaultGrace,
+		client:          client,
+		tracker:         tracker,
+		pending:         make(map[string]time.Time),
+	}
+}
+
+// Run reconciles orders until ctx is done. Every pass may spend the weight allowed by WeightPerMinute
+// for Interval and never more than the client has left below binance.DefaultPacerWeightLimit.
+// DefaultInterval is used when Interval isn't positive
+func (r *Reconciler) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	for {
+		budget := r.WeightPerMinute * int64(interval) / int64(time.Minute)
+		if left := binance.DefaultPacerWeightLimit - r.client.UsedWeight()["1m"]; left < budget {
+			budget = left
+		}
+		rep, err := r.Reconcile(ctx, budget)
+		if r.OnReport != nil {
+			r.OnReport(rep, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Reconcile runs a single pass spending at most budget request weight.
+// The pass stops before the next request once ctx is done, symbols left are deferred
+func (r *Reconciler) Reconcile(ctx context.Context, budget int64) (*Report, error) {
+	rep := &Report{}
+	symbols := r.symbols()
+	for i, symbol := range symbols {
+		if rep.Weight+WeightOpenOrders > budget {
+			rep.Deferred = symbols[i:]
+
+			break
+		}
+		complete, err := r.reconcileSymbol(ctx, symbol, budget, rep)
+		if err != nil {
+			rep.Deferred = symbols[i:]
+			r.deferred = rep.Deferred
+
+			return rep, errors.Wrapf(err, "reconcile %s", symbol)
+		}
+		if !complete {
+			rep.Deferred = symbols[i:]
+
+			break
+		}
+		rep.Symbols = append(rep.Symbols, symbol)
+	}
+	r.deferred = rep.Deferred
+	for id := range r.pending {
+		if o, ok := r.tracker.ClientOrder(id); !ok || o.OrderID != 0 {
+			delete(r.pending, id)
+		}
+	}
+
+	return rep, nil
+}
+
+// symbols returns symbols to reconcile, the ones deferred by the last pass go first
+func (r *Reconciler) symbols() []string {
+	seen := make(map[string]bool)
+	var res []string
+	add := func(symbols []string) {
+		sort.Strings(symbols)
+		for _, s := range symbols {
+			if !seen[s] {
+				seen[s] = true
+				res = append(res, s)
+			}
+		}
+	}
+	add(append([]string(nil), r.deferred...))
+	var rest []string
+	rest = append(rest, r.Symbols...)
+	for _, o := range r.tracker.Orders(ordertracker.Filter{Open: true}) {
+		rest = append(rest, o.Symbol)
+	}
+	add(rest)
+
+	return res
+}
+
+// reconcileSymbol repairs orders of the symbol, it reports false when the budget was exhausted
+func (r *Reconciler) reconcileSymbol(ctx context.Context, symbol string, budget int64, rep *Report) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	open, err := r.client.OpenOrders(&binance.OpenOrdersReq{Symbol: symbol})
+	rep.Weight += WeightOpenOrders
+	if err != nil {
+		return false, err
+	}
+	exchange := make(map[uint64]bool, len(open))
+	for _, o := range open {
+		exchange[o.OrderID] = true
+		local, ok := r.tracker.Order(symbol, o.OrderID)
+		if !ok {
+			local, ok = r.tracker.ClientOrder(o.ClientOrderID)
+			ok = ok && local.Symbol == symbol
+		}
+		// the query carries fields the order response doesn't, e.g. the stop price,
+		// they are applied but only the execution state makes the order divergent
+		if !r.tracker.ApplyQueryOrder(o) || ok && !diverged(&local, o) {
+			continue
+		}
+		d := Discrepancy{
+			Kind:          KindOrphan,
+			Symbol:        symbol,
+			OrderID:       o.OrderID,
+			ClientOrderID: o.ClientOrderID,
+			Exchange:      o,
+		}
+		if ok {
+			d.Kind = KindDivergent
+			d.Local = &local
+		}
+		rep.Discrepancies = append(rep.Discrepancies, d)
+	}
+
+	var missing []ordertracker.Order
+	now := time.Now()
+	for _, o := range r.tracker.Orders(ordertracker.Filter{Symbol: symbol, Open: true}) {
+		if o.OrderID != 0 && exchange[o.OrderID] {
+			continue
+		}
+		if o.OrderID == 0 {
+			// the order may be on the way, it is checked once the grace period passes
+			first, ok := r.pending[o.ClientOrderID]
+			if !ok {
+				r.pending[o.ClientOrderID] = now
+				continue
+			}
+			if now.Sub(first) < r.Grace {
+				continue
+			}
+		}
+		missing = append(missing, o)
+	}
+
+	return r.resolveMissing(ctx, symbol, missing, budget, rep)
+}
+
+// resolveMissing queries final state of orders which aren't open on the exchange.
+// Many orders are fetched at once by AllOrders when it is cheaper than querying them one by one
+func (r *Reconciler) resolveMissing(
+	ctx context.Context, symbol string, missing []ordertracker.Order, budget int64, rep *Report,
+) (bool, error) {
+	known := make(map[uint64]*binance.QueryOrder)
+	var fromID uint64
+	acked := 0
+	for i := range missing {
+		if id := missing[i].OrderID; id != 0 {
+			acked++
+			if fromID == 0 || id < fromID {
+				fromID = id
+			}
+		}
+	}
+	if acked*WeightQueryOrder > WeightAllOrders {
+		if rep.Weight+WeightAllOrders > budget {
+			return false, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		all, err := r.client.AllOrders(&binance.AllOrdersReq{
+			Symbol:  symbol,
+			OrderID: fromID,
+			Limit:   binance.MaxOrderLimit,
+		})
+		rep.Weight += WeightAllOrders
+		if err != nil {
+			return false, err
+		}
+		for _, o := range all {
+			known[o.OrderID] = o
+		}
+	}
+
+	for i := range missing {
+		local := missing[i]
+		o, ok := known[local.OrderID]
+		if !ok {
+			if rep.Weight+WeightQueryOrder > budget {
+				return false, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+			req := &binance.QueryOrderReq{Symbol: symbol, OrderID: local.OrderID}
+			if local.OrderID == 0 {
+				req.OrigClientOrderID = local.ClientOrderID
+			}
+			var err error
+			o, err = r.client.QueryOrder(req)
+			rep.Weight += WeightQueryOrder
+			if err != nil && !isNoSuchOrder(err) {
+				return false, err
+			}
+		}
+		delete(r.pending, local.ClientOrderID)
+		d := Discrepancy{
+			Kind:          KindPhantom,
+			Symbol:        symbol,
+			OrderID:       local.OrderID,
+			ClientOrderID: local.ClientOrderID,
+			Local:         &local,
+			Exchange:      o,
+		}
+		switch {
+		case o == nil:
+			r.tracker.Forget(symbol, local.ClientOrderID)
+		case o.Status == binance.OrderStatusNew || o.Status == binance.OrderStatusPartial:
+			// the order was placed after open orders were requested
+			r.tracker.ApplyQueryOrder(o)
+
+			continue
+		default:
+			r.tracker.ApplyQueryOrder(o)
+		}
+		rep.Discrepancies = append(rep.Discrepancies, d)
+	}
+
+	return true, nil
+}
+
+// diverged reports whether the execution state of the local order differs from the exchange
+func diverged(local *ordertracker.Order, o *binance.QueryOrder) bool {
+	return local.Status != o.Status ||
+		!local.ExecutedQty.Equal(o.ExecutedQty) ||
+		!local.CummulativeQuoteQty.Equal(o.CummulativeQuoteQty)
+}
+
+func isNoSuchOrder(err error) bool {
+	var apiErr *binance.APIError
+
+	return errors.As(err, &apiErr) && apiErr.Code == binance.ErrCodeNoSuchOrder
+}
@@ -0,0 +1,219 @@
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/ordertracker"
+	"github.com/ugi1/binance-api/reconciler"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// exchangeClient serves orders of a single symbol and records requested endpoints
+type exchangeClient struct {
+	orders    map[uint64]*binance.QueryOrder
+	endpoints []string
+}
+
+func (c *exchangeClient) Do(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+	c.endpoints = append(c.endpoints, endpoint)
+	switch req := data.(type) {
+	case *binance.OpenOrdersReq:
+		var open []*binance.QueryOrder
+		for _, o := range c.orders {
+			if o.Status == binance.OrderStatusNew || o.Status == binance.OrderStatusPartial {
+				open = append(open, o)
+			}
+		}
+
+		return json.Marshal(open)
+	case *binance.QueryOrderReq:
+		for _, o := range c.orders {
+			if o.OrderID == req.OrderID && req.OrderID != 0 || o.ClientOrderID == req.OrigClientOrderID {
+				return json.Marshal(o)
+			}
+		}
+
+		return nil, &binance.APIError{Code: binance.ErrCodeNoSuchOrder}
+	case *binance.AllOrdersReq:
+		var all []*binance.QueryOrder
+		for _, o := range c.orders {
+			if o.OrderID >= req.OrderID {
+				all = append(all, o)
+			}
+		}
+
+		return json.Marshal(all)
+	}
+	panic("unexpected request " + endpoint)
+}
+
+func (c *exchangeClient) SetWindow(int)                {}
+func (c *exchangeClient) UsedWeight() map[string]int64 { return nil }
+func (c *exchangeClient) OrderCount() map[string]int64 { return nil }
+func (c *exchangeClient) RetryAfter() int64            { return 0 }
+
+func order(id uint64, status binance.OrderStatus, executed string) *binance.QueryOrder {
+	return &binance.QueryOrder{
+		Symbol:        "BTCUSDT",
+		OrderID:       id,
+		ClientOrderID: "client" + string(rune('0'+id)),
+		Status:        status,
+		Side:          binance.OrderSideBuy,
+		Type:          binance.OrderTypeLimit,
+		OrigQty:       dec("2"),
+		ExecutedQty:   dec(executed),
+		UpdateTime:    1000 + id,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tr := ordertracker.New()
+	tr.ApplyQueryOrder(order(1, binance.OrderStatusNew, "0"))
+	tr.ApplyQueryOrder(order(2, binance.OrderStatusNew, "0"))
+	tr.ApplyQueryOrder(order(4, binance.OrderStatusNew, "0"))
+	require.NoError(t, tr.Track(binance.NewMarketOrderReq("BTCUSDT", binance.OrderSideBuy, dec("1")).WithClientOrderID("lost")))
+
+	mock := &exchangeClient{orders: map[uint64]*binance.QueryOrder{
+		1: order(1, binance.OrderStatusPartial, "1"),
+		2: order(2, binance.OrderStatusFilled, "2"),
+		3: order(3, binance.OrderStatusNew, "0"),
+		4: order(4, binance.OrderStatusNew, "0"),
+	}}
+	r := reconciler.New(binance.NewCustomClient(mock), tr)
+	r.Grace = 0
+
+	rep, err := r.Reconcile(context.Background(), reconciler.WeightOpenOrders-1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTCUSDT"}, rep.Deferred)
+	require.Empty(t, mock.endpoints)
+
+	rep, err = r.Reconcile(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTCUSDT"}, rep.Symbols)
+	require.Empty(t, rep.Deferred)
+	require.EqualValues(t, reconciler.WeightOpenOrders+reconciler.WeightQueryOrder, rep.Weight)
+
+	kinds := make(map[uint64]reconciler.Kind)
+	for _, d := range rep.Discrepancies {
+		kinds[d.OrderID] = d.Kind
+	}
+	require.Equal(t, map[uint64]reconciler.Kind{
+		1: reconciler.KindDivergent,
+		2: reconciler.KindPhantom,
+		3: reconciler.KindOrphan,
+	}, kinds)
+
+	o, ok := tr.Order("BTCUSDT", 1)
+	require.True(t, ok)
+	require.Equal(t, binance.OrderStatusPartial, o.Status)
+	o, ok = tr.Order("BTCUSDT", 2)
+	require.True(t, ok)
+	require.Equal(t, binance.OrderStatusFilled, o.Status)
+	_, ok = tr.Order("BTCUSDT", 3)
+	require.True(t, ok)
+
+	// the unacknowledged order is checked after the grace period
+	rep, err = r.Reconcile(context.Background(), 100)
+	require.NoError(t, err)
+	require.Len(t, rep.Discrepancies, 1)
+	require.Equal(t, reconciler.KindPhantom, rep.Discrepancies[0].Kind)
+	require.Equal(t, "lost", rep.Discrepancies[0].ClientOrderID)
+	require.Nil(t, rep.Discrepancies[0].Exchange)
+	_, ok = tr.ClientOrder("lost")
+	require.False(t, ok)
+
+	rep, err = r.Reconcile(context.Background(), 100)
+	require.NoError(t, err)
+	require.Empty(t, rep.Discrepancies)
+
+	// cancelled pass stops before the next request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mock.endpoints = nil
+	rep, err = r.Reconcile(ctx, 100)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []string{"BTCUSDT"}, rep.Deferred)
+	require.Empty(t, mock.endpoints)
+}
+
+func TestRunInterval(t *testing.T) {
+	mock := &exchangeClient{}
+	r := reconciler.New(binance.NewCustomClient(mock), ordertracker.New())
+	r.Symbols = []string{"BTCUSDT"}
+	r.Interval = 0
+	reports := 0
+	r.OnReport = func(*reconciler.Report, error) {
+		reports++
+	}
+	// non-positive interval falls back to the default one instead of spinning
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, r.Run(ctx), context.DeadlineExceeded)
+	require.Equal(t, 1, reports)
+	require.Len(t, mock.endpoints, 1)
+}
+
+func TestReconcileFreshStopLimit(t *testing.T) {
+	tr := ordertracker.New()
+	// the full response doesn't carry the stop price
+	require.True(t, tr.ApplyOrderResp(&binance.OrderRespFull{
+		Symbol:              "BTCUSDT",
+		OrderID:             7,
+		OrderListID:         -1,
+		ClientOrderID:       "stop",
+		TransactTime:        1000,
+		Price:               dec("90"),
+		OrigQty:             dec("1"),
+		ExecutedQty:         dec("0"),
+		CummulativeQuoteQty: dec("0"),
+		Status:              binance.OrderStatusNew,
+		Type:                binance.OrderTypeStopLossLimit,
+		Side:                binance.OrderSideSell,
+		TimeInForce:         "GTC",
+	}))
+
+	o := order(7, binance.OrderStatusNew, "0")
+	o.ClientOrderID = "stop"
+	o.Side = binance.OrderSideSell
+	o.Type = binance.OrderTypeStopLossLimit
+	o.Price = dec("90")
+	o.StopPrice = dec("95")
+	o.OrigQty = dec("1")
+	o.CummulativeQuoteQty = dec("0")
+	o.UpdateTime = 1000
+	mock := &exchangeClient{orders: map[uint64]*binance.QueryOrder{7: o}}
+
+	rep, err := reconciler.New(binance.NewCustomClient(mock), tr).Reconcile(context.Background(), 100)
+	require.NoError(t, err)
+	require.Empty(t, rep.Discrepancies)
+	local, ok := tr.Order("BTCUSDT", 7)
+	require.True(t, ok)
+	require.Equal(t, "95", local.StopPrice.String())
+}
+
+func TestReconcileAllOrders(t *testing.T) {
+	tr := ordertracker.New()
+	mock := &exchangeClient{orders: make(map[uint64]*binance.QueryOrder)}
+	for id := uint64(1); id <= 8; id++ {
+		tr.ApplyQueryOrder(order(id, binance.OrderStatusNew, "0"))
+		mock.orders[id] = order(id, binance.OrderStatusCanceled, "0")
+	}
+	r := reconciler.New(binance.NewCustomClient(mock), tr)
+
+	rep, err := r.Reconcile(context.Background(), 100)
+	require.NoError(t, err)
+	require.Len(t, rep.Discrepancies, 8)
+	require.Equal(t, []string{binance.EndpointOpenOrders, binance.EndpointOrdersAll}, mock.endpoints)
+	require.EqualValues(t, reconciler.WeightOpenOrders+reconciler.WeightAllOrders, rep.Weight)
+	require.Empty(t, tr.Orders(ordertracker.Filter{Open: true}))
+}
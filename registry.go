@@ -0,0 +1,321 @@
+package binance
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+)
+
+// SymbolEventType represents the kind of change detected between two exchangeInfo snapshots
+type SymbolEventType string
+
+const (
+	SymbolEventAdded              SymbolEventType = "ADDED"
+	SymbolEventRemoved            SymbolEventType = "REMOVED"
+	SymbolEventStatusChanged      SymbolEventType = "STATUS_CHANGED"
+	SymbolEventFiltersChanged     SymbolEventType = "FILTERS_CHANGED"
+	SymbolEventPermissionsChanged SymbolEventType = "PERMISSIONS_CHANGED"
+)
+
+// SymbolEvent is emitted by Registry when a symbol changes after refresh
+type SymbolEvent struct {
+	Type   SymbolEventType
+	Symbol string
+	Old    *SymbolInfo // Old is nil for SymbolEventAdded
+	New    *SymbolInfo // New is nil for SymbolEventRemoved
+}
+
+const (
+	// DefaultRegistryMinRefresh limits how often Registry refetches exchangeInfo after filter failures
+	DefaultRegistryMinRefresh = time.Minute
+	// DefaultRegistryInterval is the refresh interval of Run when the given interval isn't positive
+	DefaultRegistryInterval = 10 * time.Minute
+)
+
+// Registry caches exchangeInfo and indexes symbols by name and by base/quote asset
+type Registry struct {
+	client *Client
+
+	// MinRefresh is the minimal interval between refreshes triggered by RefreshOnError
+	MinRefresh time.Duration
+
+	mu          sync.RWMutex
+	info        *ExchangeInfo
+	symbols     map[string]*SymbolInfo
+	byBase      map[string][]*SymbolInfo
+	byQuote     map[string][]*SymbolInfo
+	updated     time.Time
+	subscribers []chan SymbolEvent
+
+	refreshMu sync.Mutex
+}
+
+// NewRegistry creates an empty registry, call Refresh or Run to load exchangeInfo
+func NewRegistry(client *Client) *Registry {
+	return &Registry{
+		client:     client,
+		MinRefresh: DefaultRegistryMinRefresh,
+	}
+}
+
+// Refresh fetches exchangeInfo, rebuilds indexes and notifies subscribers about changed symbols
+func (r *Registry) Refresh() error {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+
+	info, err := r.client.ExchangeInfo()
+	if err != nil {
+		return err
+	}
+	r.update(info)
+
+	return nil
+}
+
+// RefreshOnError refreshes the registry if err is a filter failure returned by the exchange or by ValidateOrder,
+// since it usually means cached filters are stale. Refreshes are limited to one per MinRefresh.
+// It reports whether refresh was performed.
+func (r *Registry) RefreshOnError(err error) (bool, error) {
+	if !isFilterFailure(err) {
+		return false, nil
+	}
+	r.mu.RLock()
+	fresh := time.Since(r.updated) < r.MinRefresh
+	r.mu.RUnlock()
+	if fresh {
+		return false, nil
+	}
+
+	return true, r.Refresh()
+}
+
+func isFilterFailure(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == ErrCodeFilterFailure
+	}
+	var validationErr *OrderValidationError
+
+	return errors.As(err, &validationErr)
+}
+
+// Run refreshes the registry every interval until ctx is done. Refresh errors are passed to onError if it's not nil.
+// DefaultRegistryInterval is used when interval isn't positive
+func (r *Registry) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	if interval <= 0 {
+		interval = DefaultRegistryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Refresh(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Subscribe returns channel with symbol change events. Events are sent synchronously by Refresh,
+// so the channel must be drained to not block refreshes
+func (r *Registry) Subscribe(buffer int) <-chan SymbolEvent {
+	ch := make(chan SymbolEvent, buffer)
+	r.mu.Lock()
+	r.subscribers = append(r.subscribers, ch)
+	r.mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe removes the subscription and closes its channel.
+// It waits until the in-flight refresh delivers its events
+func (r *Registry) Unsubscribe(ch <-chan SymbolEvent) {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, sub := range r.subscribers {
+		if sub == ch {
+			r.subscribers = append(r.subscribers[:i], r.subscribers[i+1:]...)
+			close(sub)
+
+			return
+		}
+	}
+}
+
+// ExchangeInfo returns the last fetched exchangeInfo or nil if the registry wasn't refreshed yet
+func (r *Registry) ExchangeInfo() *ExchangeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.info
+}
+
+// Updated returns the time of the last successful refresh
+func (r *Registry) Updated() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.updated
+}
+
+// Symbol returns cached symbol info
+func (r *Registry) Symbol(symbol string) (*SymbolInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.symbols[symbol]
+
+	return s, ok
+}
+
+// Pair returns cached symbol info for the given base and quote assets
+func (r *Registry) Pair(base, quote string) (*SymbolInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, s := range r.byBase[base] {
+		if s.QuoteAsset == quote {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
+
+// Symbols returns all cached symbols
+func (r *Registry) Symbols() []*SymbolInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.info == nil {
+		return nil
+	}
+	res := make([]*SymbolInfo, 0, len(r.symbols))
+	for i := range r.info.Symbols {
+		res = append(res, &r.info.Symbols[i])
+	}
+
+	return res
+}
+
+// ByBaseAsset returns all symbols with the given base asset
+func (r *Registry) ByBaseAsset(asset string) []*SymbolInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*SymbolInfo(nil), r.byBase[asset]...)
+}
+
+// ByQuoteAsset returns all symbols with the given quote asset
+func (r *Registry) ByQuoteAsset(asset string) []*SymbolInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*SymbolInfo(nil), r.byQuote[asset]...)
+}
+
+func (r *Registry) update(info *ExchangeInfo) {
+	symbols := make(map[string]*SymbolInfo, len(info.Symbols))
+	byBase := make(map[string][]*SymbolInfo)
+	byQuote := make(map[string][]*SymbolInfo)
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		symbols[s.Symbol] = s
+		byBase[s.BaseAsset] = append(byBase[s.BaseAsset], s)
+		byQuote[s.QuoteAsset] = append(byQuote[s.QuoteAsset], s)
+	}
+
+	r.mu.Lock()
+	var events []SymbolEvent
+	// the first load isn't reported as additions
+	if r.symbols != nil {
+		events = diffSymbols(r.info, r.symbols, info, symbols)
+	}
+	r.info = info
+	r.symbols = symbols
+	r.byBase = byBase
+	r.byQuote = byQuote
+	r.updated = time.Now()
+	subscribers := append([]chan SymbolEvent(nil), r.subscribers...)
+	r.mu.Unlock()
+
+	for _, e := range events {
+		for _, sub := range subscribers {
+			sub <- e
+		}
+	}
+}
+
+func diffSymbols(oldInfo *ExchangeInfo, oldSymbols map[string]*SymbolInfo, newInfo *ExchangeInfo, newSymbols map[string]*SymbolInfo) []SymbolEvent {
+	var events []SymbolEvent
+	for i := range newInfo.Symbols {
+		n := &newInfo.Symbols[i]
+		o, ok := oldSymbols[n.Symbol]
+		if !ok {
+			events = append(events, SymbolEvent{Type: SymbolEventAdded, Symbol: n.Symbol, New: n})
+
+			continue
+		}
+		if o.Status != n.Status {
+			events = append(events, SymbolEvent{Type: SymbolEventStatusChanged, Symbol: n.Symbol, Old: o, New: n})
+		}
+		if !filtersEqual(o.Filters, n.Filters) {
+			events = append(events, SymbolEvent{Type: SymbolEventFiltersChanged, Symbol: n.Symbol, Old: o, New: n})
+		}
+		if !permissionsEqual(o.Permissions, n.Permissions) || !permissionSetsEqual(o.PermissionSets, n.PermissionSets) {
+			events = append(events, SymbolEvent{Type: SymbolEventPermissionsChanged, Symbol: n.Symbol, Old: o, New: n})
+		}
+	}
+	for i := range oldInfo.Symbols {
+		o := &oldInfo.Symbols[i]
+		if _, ok := newSymbols[o.Symbol]; !ok {
+			events = append(events, SymbolEvent{Type: SymbolEventRemoved, Symbol: o.Symbol, Old: o})
+		}
+	}
+
+	return events
+}
+
+// filtersEqual compares filters by their encoded values, so decimals with different precision are still equal
+func filtersEqual(a, b []SymbolInfoFilter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ab, errA := json.Marshal(a)
+	bb, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(ab, bb)
+}
+
+func permissionsEqual(a, b []AccountType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func permissionSetsEqual(a, b [][]AccountType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !permissionsEqual(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+package binance_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) TestRegistry() {
+	fixture, err := os.ReadFile("testdata/exchange_info.json")
+	s.Require().NoError(err)
+
+	calls := 0
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointExchangeInfo, endpoint)
+		calls++
+		return fixture, nil
+	}
+
+	r := binance.NewRegistry(s.api)
+	s.Require().NoError(r.Refresh())
+	s.Require().Equal(1, calls)
+
+	btc, ok := r.Symbol("BTCUSDT")
+	s.Require().True(ok)
+	s.Require().Equal("BTC", btc.BaseAsset)
+	_, ok = r.Symbol("ETHUSDT")
+	s.Require().False(ok)
+	ltc, ok := r.Pair("LTC", "BTC")
+	s.Require().True(ok)
+	s.Require().Equal("LTCBTC", ltc.Symbol)
+	s.Require().Len(r.ByQuoteAsset("BTC"), 1)
+	s.Require().Len(r.ByBaseAsset("BTC"), 1)
+	s.Require().Len(r.Symbols(), 2)
+
+	events := r.Subscribe(10)
+
+	// LTCBTC resumes trading with a new tick size, BTCUSDT is delisted
+	changed := bytes.Replace(fixture, []byte(`"status": "BREAK"`), []byte(`"status": "TRADING"`), 1)
+	changed = bytes.Replace(changed, []byte(`"tickSize": "0.00000100"`), []byte(`"tickSize": "0.00000010"`), 1)
+	changed = bytes.Replace(changed, []byte(`"symbol": "BTCUSDT"`), []byte(`"symbol": "ETHUSDT"`), 1)
+	fixture = changed
+
+	// filter failures are throttled by MinRefresh
+	refreshed, err := r.RefreshOnError(&binance.APIError{Code: binance.ErrCodeFilterFailure})
+	s.Require().NoError(err)
+	s.Require().False(refreshed)
+
+	r.MinRefresh = 0
+	refreshed, err = r.RefreshOnError(&binance.APIError{Code: -1021})
+	s.Require().NoError(err)
+	s.Require().False(refreshed)
+	refreshed, err = r.RefreshOnError(&binance.APIError{Code: binance.ErrCodeFilterFailure})
+	s.Require().NoError(err)
+	s.Require().True(refreshed)
+	s.Require().Equal(2, calls)
+
+	got := map[binance.SymbolEventType]string{}
+	for len(events) > 0 {
+		e := <-events
+		got[e.Type] = e.Symbol
+	}
+	s.Require().Equal(map[binance.SymbolEventType]string{
+		binance.SymbolEventAdded:          "ETHUSDT",
+		binance.SymbolEventRemoved:        "BTCUSDT",
+		binance.SymbolEventStatusChanged:  "LTCBTC",
+		binance.SymbolEventFiltersChanged: "LTCBTC",
+	}, got)
+
+	ltc, ok = r.Symbol("LTCBTC")
+	s.Require().True(ok)
+	s.Require().Equal("0.0000001", ltc.PriceFilter().TickSize.String())
+
+	// the permission set of ETHUSDT loses margin trading
+	fixture = bytes.Replace(fixture, []byte(`"permissionSets": [["SPOT", "MARGIN"]]`), []byte(`"permissionSets": [["SPOT"]]`), 1)
+	s.Require().NoError(r.Refresh())
+	s.Require().Len(events, 1)
+	e := <-events
+	s.Require().Equal(binance.SymbolEventPermissionsChanged, e.Type)
+	s.Require().Equal("ETHUSDT", e.Symbol)
+	s.Require().Equal([][]binance.AccountType{{binance.AccountTypeSpot}}, e.New.PermissionSets)
+
+	// non-positive interval falls back to the default one
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.Run(ctx, 0, nil)
+
+	r.Unsubscribe(events)
+	_, open := <-events
+	s.Require().False(open)
+}
@@ -0,0 +1,211 @@
+package binance
+
+import (
+	"time"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// ResampledKlines is a kline aggregated from klines of a finer interval
+type ResampledKlines struct {
+	Klines
+	// Partial is set when source klines don't cover the whole interval or the last source kline isn't final yet
+	Partial bool
+}
+
+// resampledKlinesJSON is the JSON form of ResampledKlines, it hides the promoted Klines methods
+type resampledKlinesJSON struct {
+	Klines  *Klines `json:"kline"`
+	Partial bool    `json:"partial"`
+}
+
+// MarshalJSON encodes the kline in Binance array format along with the partial flag,
+// e.g. {"kline":[1499040000000,"0.01634790",...],"partial":true}
+func (k ResampledKlines) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resampledKlinesJSON{Klines: &k.Klines, Partial: k.Partial})
+}
+
+// UnmarshalJSON decodes the kline encoded by MarshalJSON
+func (k *ResampledKlines) UnmarshalJSON(data []byte) error {
+	if k == nil {
+		return ErrNilUnmarshal
+	}
+
+	if isNull(data) {
+		return nil
+	}
+	v := resampledKlinesJSON{Klines: &k.Klines}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	k.Partial = v.Partial
+
+	return nil
+}
+
+// Resampler aggregates klines into a coarser interval, e.g. 1m klines into 90m bars.
+// Bars are aligned to multiples of the interval since Unix epoch, or to calendar boundaries for 1w and 1M.
+// Source klines must be of an interval which divides the target one.
+//
+// Resampler can be fed by REST results with Resample or by live updates with Add, it isn't safe for concurrent use.
+type Resampler struct {
+	truncate func(t time.Time) time.Time
+	next     func(t time.Time) time.Time
+
+	active    bool
+	start     uint64
+	end       uint64
+	acc       Klines
+	folded    int
+	lastOpen  uint64
+	lastClose uint64
+	gap       bool
+	cur       *Klines
+}
+
+// NewResampler creates resampler for a custom interval like 2m, 10m or 90m
+func NewResampler(interval time.Duration) (*Resampler, error) {
+	if interval < time.Second || interval%time.Second != 0 {
+		return nil, ErrInvalidResampleInterval
+	}
+	ms := interval.Milliseconds()
+	truncate := func(t time.Time) time.Time {
+		t = t.UTC()
+		m := t.UnixMilli()
+
+		return time.UnixMilli(m - m%ms).UTC()
+	}
+
+	return &Resampler{
+		truncate: truncate,
+		next: func(t time.Time) time.Time {
+			return truncate(t).Add(interval)
+		},
+	}, nil
+}
+
+// NewIntervalResampler creates resampler for Binance interval, 1w and 1M bars follow the calendar
+func NewIntervalResampler(interval KlineInterval) (*Resampler, error) {
+	if !interval.Valid() {
+		return nil, ErrInvalidKlineInterval
+	}
+
+	return &Resampler{
+		truncate: interval.Truncate,
+		next:     interval.Next,
+	}, nil
+}
+
+// Resample aggregates klines sorted by open time and returns all bars including the last one,
+// which is partial if klines end in the middle of the interval. Resampler state is reset afterwards
+func (r *Resampler) Resample(klines []*Klines) []*ResampledKlines {
+	var res []*ResampledKlines
+	for _, k := range klines {
+		if _, closed := r.Add(k, true); closed != nil {
+			res = append(res, closed)
+		}
+	}
+	if last := r.Flush(); last != nil {
+		res = append(res, last)
+	}
+
+	return res
+}
+
+// Add adds source kline, final reports whether the source kline is closed, e.g. KlinesUpdate Final flag.
+// Non final klines with the same open time replace each other. It returns the current state of the bar
+// containing k and the previous bar if k starts a new one. Klines older than the current bar are ignored
+func (r *Resampler) Add(k *Klines, final bool) (bar, closed *ResampledKlines) {
+	t := k.OpenAt()
+	start := TimeToMs(r.truncate(t))
+	if r.active && start != r.start {
+		if start < r.start {
+			return nil, nil
+		}
+		closed = r.Flush()
+	}
+	if !r.active {
+		r.active = true
+		r.start = start
+		r.end = TimeToMs(r.next(t))
+	}
+
+	if r.cur != nil && k.OpenTime != r.cur.OpenTime {
+		if k.OpenTime < r.cur.OpenTime {
+			return r.bar(), closed
+		}
+		// the stream moved to the next source kline without the final update
+		r.fold(r.cur)
+		r.cur = nil
+	}
+	switch {
+	case r.folded > 0 && k.OpenTime <= r.lastOpen:
+		// already aggregated
+	case final:
+		r.fold(k)
+		r.cur = nil
+	default:
+		cur := *k
+		r.cur = &cur
+	}
+
+	return r.bar(), closed
+}
+
+// Flush returns the current bar and resets resampler, it returns nil if there is no bar
+func (r *Resampler) Flush() *ResampledKlines {
+	if !r.active {
+		return nil
+	}
+	bar := r.bar()
+	*r = Resampler{truncate: r.truncate, next: r.next}
+
+	return bar
+}
+
+func (r *Resampler) fold(k *Klines) {
+	if r.folded == 0 {
+		r.gap = k.OpenTime != r.start
+		r.acc = *k
+	} else {
+		r.gap = r.gap || k.OpenTime != r.lastClose+1
+		mergeKlines(&r.acc, k)
+	}
+	r.folded++
+	r.lastOpen = k.OpenTime
+	r.lastClose = k.CloseTime
+}
+
+func (r *Resampler) bar() *ResampledKlines {
+	bar := &ResampledKlines{Partial: r.gap || r.cur != nil || r.lastClose != r.end-1}
+	switch {
+	case r.folded == 0 && r.cur == nil:
+		return nil
+	case r.folded == 0:
+		bar.Klines = *r.cur
+	default:
+		bar.Klines = r.acc
+		if r.cur != nil {
+			mergeKlines(&bar.Klines, r.cur)
+		}
+	}
+	bar.OpenTime = r.start
+	bar.CloseTime = r.end - 1
+
+	return bar
+}
+
+func mergeKlines(dst, k *Klines) {
+	if k.High.GreaterThan(dst.High) {
+		dst.High = k.High
+	}
+	if k.Low.LessThan(dst.Low) {
+		dst.Low = k.Low
+	}
+	dst.ClosePrice = k.ClosePrice
+	dst.Volume = dst.Volume.Add(k.Volume)
+	dst.QuoteAssetVolume = dst.QuoteAssetVolume.Add(k.QuoteAssetVolume)
+	dst.Trades += k.Trades
+	dst.TakerBuyBaseAssetVolume = dst.TakerBuyBaseAssetVolume.Add(k.TakerBuyBaseAssetVolume)
+	dst.TakerBuyQuoteAssetVolume = dst.TakerBuyQuoteAssetVolume.Add(k.TakerBuyQuoteAssetVolume)
+}
@@ -0,0 +1,148 @@
+package binance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+func testKline(open time.Time, d time.Duration, o, h, l, c int64) *binance.Klines {
+	return &binance.Klines{
+		OpenTime:                 binance.TimeToMs(open),
+		CloseTime:                binance.TimeToMs(open.Add(d)) - 1,
+		OpenPrice:                decimal.NewFromInt(o),
+		High:                     decimal.NewFromInt(h),
+		Low:                      decimal.NewFromInt(l),
+		ClosePrice:               decimal.NewFromInt(c),
+		Volume:                   decimal.NewFromInt(2),
+		QuoteAssetVolume:         decimal.NewFromInt(20),
+		Trades:                   3,
+		TakerBuyBaseAssetVolume:  decimal.NewFromInt(1),
+		TakerBuyQuoteAssetVolume: decimal.NewFromInt(10),
+	}
+}
+
+func TestResample(t *testing.T) {
+	r, err := binance.NewResampler(10 * time.Minute)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 10, 3, 0, 0, time.UTC)
+	var klines []*binance.Klines
+	for i := 0; i < 25; i++ {
+		p := int64(100 + i)
+		klines = append(klines, testKline(start.Add(time.Duration(i)*time.Minute), time.Minute, p, p+5, p-5, p+1))
+	}
+	bars := r.Resample(klines)
+	require.Len(t, bars, 3)
+
+	require.True(t, bars[0].Partial)
+	require.Equal(t, binance.TimeToMs(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)), bars[0].OpenTime)
+
+	full := bars[1]
+	require.False(t, full.Partial)
+	require.Equal(t, binance.TimeToMs(time.Date(2024, 1, 1, 10, 10, 0, 0, time.UTC)), full.OpenTime)
+	require.Equal(t, binance.TimeToMs(time.Date(2024, 1, 1, 10, 20, 0, 0, time.UTC))-1, full.CloseTime)
+	require.Equal(t, "107", full.OpenPrice.String())
+	require.Equal(t, "121", full.High.String())
+	require.Equal(t, "102", full.Low.String())
+	require.Equal(t, "117", full.ClosePrice.String())
+	require.Equal(t, "20", full.Volume.String())
+	require.Equal(t, "200", full.QuoteAssetVolume.String())
+	require.Equal(t, 30, full.Trades)
+	require.Equal(t, "10", full.TakerBuyBaseAssetVolume.String())
+	require.Equal(t, "100", full.TakerBuyQuoteAssetVolume.String())
+
+	require.True(t, bars[2].Partial)
+	require.Equal(t, 24, bars[2].Trades)
+
+	// gap inside the bar
+	bars = r.Resample([]*binance.Klines{klines[7], klines[9]})
+	require.Len(t, bars, 1)
+	require.True(t, bars[0].Partial)
+
+	_, err = binance.NewResampler(time.Millisecond)
+	require.ErrorIs(t, err, binance.ErrInvalidResampleInterval)
+}
+
+func TestResamplerStream(t *testing.T) {
+	r, err := binance.NewResampler(2 * time.Minute)
+	require.NoError(t, err)
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	bar, closed := r.Add(testKline(start, time.Minute, 10, 11, 9, 10), false)
+	require.Nil(t, closed)
+	require.True(t, bar.Partial)
+	bar, _ = r.Add(testKline(start, time.Minute, 10, 15, 9, 14), false)
+	require.Equal(t, "15", bar.High.String())
+	require.Equal(t, 3, bar.Trades)
+	bar, _ = r.Add(testKline(start, time.Minute, 10, 15, 8, 12), true)
+	require.True(t, bar.Partial)
+	require.Equal(t, "8", bar.Low.String())
+
+	bar, _ = r.Add(testKline(start.Add(time.Minute), time.Minute, 12, 13, 11, 13), false)
+	require.True(t, bar.Partial)
+	require.Equal(t, 6, bar.Trades)
+	bar, _ = r.Add(testKline(start.Add(time.Minute), time.Minute, 12, 13, 11, 12), true)
+	require.False(t, bar.Partial)
+	require.Equal(t, "12", bar.ClosePrice.String())
+	require.Equal(t, 6, bar.Trades)
+
+	// duplicate final update doesn't change the bar
+	bar, _ = r.Add(testKline(start.Add(time.Minute), time.Minute, 12, 13, 11, 12), true)
+	require.Equal(t, 6, bar.Trades)
+
+	bar, closed = r.Add(testKline(start.Add(2*time.Minute), time.Minute, 12, 12, 12, 12), false)
+	require.NotNil(t, closed)
+	require.False(t, closed.Partial)
+	require.Equal(t, "10", closed.OpenPrice.String())
+	require.Equal(t, binance.TimeToMs(start.Add(2*time.Minute)), bar.OpenTime)
+}
+
+func TestResampleCalendar(t *testing.T) {
+	r, err := binance.NewIntervalResampler(binance.KlineInterval1month)
+	require.NoError(t, err)
+
+	var klines []*binance.Klines
+	for d := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); d.Month() == time.February; d = d.AddDate(0, 0, 1) {
+		klines = append(klines, testKline(d, 24*time.Hour, 1, 2, 1, 2))
+	}
+	bars := r.Resample(klines)
+	require.Len(t, bars, 1)
+	require.False(t, bars[0].Partial)
+	require.Equal(t, 29*3, bars[0].Trades)
+	require.Equal(t, binance.TimeToMs(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))-1, bars[0].CloseTime)
+}
+
+func TestResampledKlinesJSON(t *testing.T) {
+	k := binance.ResampledKlines{
+		Klines:  *testKline(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Hour, 1, 2, 1, 2),
+		Partial: true,
+	}
+	b, err := json.Marshal(&k)
+	require.NoError(t, err)
+	require.Equal(t, `{"kline":[1706745600000,"1","2","1","2","2",1706749199999,"20",3,"1","10","0"],"partial":true}`, string(b))
+
+	var decoded binance.ResampledKlines
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.True(t, decoded.Partial)
+	require.Equal(t, k.OpenTime, decoded.OpenTime)
+	require.True(t, k.ClosePrice.Equal(decoded.ClosePrice))
+
+	// slices of bars, as returned by Resample
+	b, err = json.Marshal([]*binance.ResampledKlines{&k, {Klines: k.Klines}})
+	require.NoError(t, err)
+	var bars []*binance.ResampledKlines
+	require.NoError(t, json.Unmarshal(b, &bars))
+	require.Len(t, bars, 2)
+	require.True(t, bars[0].Partial)
+	require.False(t, bars[1].Partial)
+	require.Equal(t, k.CloseTime, bars[1].CloseTime)
+	b2, err := json.Marshal(bars)
+	require.NoError(t, err)
+	require.Equal(t, string(b), string(b2))
+}
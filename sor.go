@@ -0,0 +1,201 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+// WorkingFloor is the venue where the order works
+type WorkingFloor string
+
+const (
+	WorkingFloorExchange WorkingFloor = "EXCHANGE"
+	WorkingFloorSOR      WorkingFloor = "SOR"
+)
+
+type AllocationType string
+
+const (
+	AllocationTypeSOR AllocationType = "SOR"
+)
+
+type MatchType string
+
+const (
+	MatchTypeOnePartyTradeReport MatchType = "ONE_PARTY_TRADE_REPORT"
+)
+
+// SOR is the smart order routing configuration, orders on any of Symbols can be routed to the others
+type SOR struct {
+	BaseAsset string   `json:"baseAsset"`
+	Symbols   []string `json:"symbols"`
+}
+
+// SOR returns the smart order routing configuration including the symbol
+func (e *ExchangeInfo) SOR(symbol string) (*SOR, bool) {
+	for i := range e.SORs {
+		for _, s := range e.SORs[i].Symbols {
+			if s == symbol {
+				return &e.SORs[i], true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// SOROrderReq places an order using smart order routing, only LIMIT and MARKET orders with quantity are supported
+type SOROrderReq struct {
+	Symbol                  string                  `url:"symbol"`
+	Side                    OrderSide               `url:"side"`
+	Type                    OrderType               `url:"type"`
+	TimeInForce             TimeInForce             `url:"timeInForce,omitempty"`
+	Quantity                string                  `url:"quantity"`
+	Price                   string                  `url:"price,omitempty"`
+	NewClientOrderID        string                  `url:"newClientOrderId,omitempty"`
+	StrategyID              int                     `url:"strategyId,omitempty"`
+	StrategyType            int                     `url:"strategyType,omitempty"` // Should be more than 1000000
+	IcebergQty              string                  `url:"icebergQty,omitempty"`
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+}
+
+type SOROrderFill struct {
+	MatchType       MatchType       `json:"matchType"`
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TradeID         int64           `json:"tradeId"`
+	AllocID         int64           `json:"allocId"`
+}
+
+type SOROrderResp struct {
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 uint64                  `json:"orderId"`
+	OrderListID             int64                   `json:"orderListId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	TransactTime            uint64                  `json:"transactTime"`
+	Price                   decimal.Decimal         `json:"price"`
+	OrigQty                 decimal.Decimal         `json:"origQty"`
+	ExecutedQty             decimal.Decimal         `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal         `json:"cummulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	WorkingTime             uint64                  `json:"workingTime"`
+	WorkingFloor            WorkingFloor            `json:"workingFloor"`
+	UsedSor                 bool                    `json:"usedSor"`
+	StrategyID              int                     `json:"strategyId,omitempty"`
+	StrategyType            int                     `json:"strategyType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode,omitempty"`
+	PreventedMatchID        int64                   `json:"preventedMatchId,omitempty"`
+	PreventedQuantity       decimal.Decimal         `json:"preventedQuantity"`
+	Fills                   []SOROrderFill          `json:"fills"`
+}
+
+// AllocationsReq represents the request used for querying allocations of SOR orders
+// Remark: FromAllocationID can't be combined with StartTime and EndTime
+type AllocationsReq struct {
+	Symbol           string `url:"symbol"`
+	StartTime        uint64 `url:"startTime,omitempty"`
+	EndTime          uint64 `url:"endTime,omitempty"`
+	FromAllocationID int64  `url:"fromAllocationId,omitempty"`
+	Limit            int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+	OrderID          uint64 `url:"orderId,omitempty"`
+}
+
+// Allocation is a transfer of an asset from the allocator to the account as a result of SOR order execution
+type Allocation struct {
+	Symbol          string          `json:"symbol"`
+	AllocationID    int64           `json:"allocationId"`
+	AllocationType  AllocationType  `json:"allocationType"`
+	OrderID         uint64          `json:"orderId"`
+	OrderListID     int64           `json:"orderListId"`
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	QuoteQty        decimal.Decimal `json:"quoteQty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	Time            uint64          `json:"time"`
+	Buyer           bool            `json:"isBuyer"`
+	Maker           bool            `json:"isMaker"`
+	Allocator       bool            `json:"isAllocator"`
+}
+
+func (r *SOROrderReq) validate() error {
+	if r.Symbol == "" {
+		return ErrEmptySymbol
+	}
+	if r.Type != OrderTypeLimit && r.Type != OrderTypeMarket {
+		return ErrInvalidOrderType
+	}
+
+	return orderParams{
+		typ:          r.Type,
+		side:         r.Side,
+		quantity:     r.Quantity,
+		price:        r.Price,
+		timeInForce:  &r.TimeInForce,
+		icebergQty:   r.IcebergQty,
+		strategyType: r.StrategyType,
+	}.validate()
+}
+
+// NewSOROrder places an order using smart order routing and returns full order info
+func (c *Client) NewSOROrder(req *SOROrderReq) (*SOROrderResp, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = OrderRespTypeFull
+	res, err := c.Do(fasthttp.MethodPost, EndpointSOROrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SOROrderResp{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// NewSOROrderTest validates a new SOR order but does not send it into the matching engine
+func (c *Client) NewSOROrderTest(req *SOROrderReq) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	if err := req.validate(); err != nil {
+		return err
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointSOROrderTest, req, true, false)
+
+	return err
+}
+
+// Allocations get allocations resulting from SOR order placement
+func (c *Client) Allocations(req *AllocationsReq) ([]*Allocation, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.FromAllocationID != 0 && (req.StartTime != 0 || req.EndTime != 0) {
+		return nil, ErrInvalidTimeRange
+	}
+	if req.Limit <= 0 || req.Limit > MaxOrderLimit {
+		req.Limit = DefaultOrderLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAllocations, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Allocation
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
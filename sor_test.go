@@ -0,0 +1,110 @@
+package binance_test
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) TestNewSOROrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(binance.EndpointSOROrder, endpoint)
+		req := data.(*binance.SOROrderReq)
+		s.Require().Equal(binance.TimeInForceGTC, req.TimeInForce)
+		s.Require().EqualValues(binance.OrderRespTypeFull, req.OrderRespType)
+
+		return []byte(`{"symbol":"BTCUSDT","orderId":2,"orderListId":-1,"clientOrderId":"sBI1KM6nNtOfj5tccZSKly",
+			"transactTime":1689149087774,"price":"31000.00000000","origQty":"0.50000000","executedQty":"0.50000000",
+			"cummulativeQuoteQty":"14000.00000000","status":"FILLED","timeInForce":"GTC","type":"LIMIT","side":"BUY",
+			"workingTime":1689149087774,"fills":[{"matchType":"ONE_PARTY_TRADE_REPORT","price":"28000.00000000",
+			"qty":"0.50000000","commission":"0.00000000","commissionAsset":"BTC","tradeId":-1,"allocId":0}],
+			"workingFloor":"SOR","selfTradePreventionMode":"NONE","usedSor":true}`), nil
+	}
+	resp, err := s.api.NewSOROrder(&binance.SOROrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeLimit,
+		Quantity: "0.5",
+		Price:    "31000",
+	})
+	s.Require().NoError(err)
+	s.Require().True(resp.UsedSor)
+	s.Require().Equal(binance.WorkingFloorSOR, resp.WorkingFloor)
+	s.Require().Len(resp.Fills, 1)
+	s.Require().Equal(binance.MatchTypeOnePartyTradeReport, resp.Fills[0].MatchType)
+	s.Require().Equal("28000.00000000", binance.FormatDecimal(resp.Fills[0].Price))
+
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointSOROrderTest, endpoint)
+
+		return []byte(`{}`), nil
+	}
+	s.Require().NoError(s.api.NewSOROrderTest(&binance.SOROrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideSell,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "0.5",
+	}))
+
+	for name, tc := range map[string]struct {
+		req *binance.SOROrderReq
+		err error
+	}{
+		"nil":            {nil, binance.ErrNilRequest},
+		"no symbol":      {&binance.SOROrderReq{Type: binance.OrderTypeMarket, Quantity: "1"}, binance.ErrEmptySymbol},
+		"no side":        {&binance.SOROrderReq{Symbol: "BTCUSDT", Type: binance.OrderTypeMarket, Quantity: "1"}, binance.ErrInvalidOrderSide},
+		"no quantity":    {&binance.SOROrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimit, Price: "1"}, binance.ErrEmptyLimit},
+		"market no qty":  {&binance.SOROrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket}, binance.ErrEmptyMarket},
+		"no price":       {&binance.SOROrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimit, Quantity: "1"}, binance.ErrEmptyLimit},
+		"stop loss":      {&binance.SOROrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeStopLoss, Quantity: "1"}, binance.ErrInvalidOrderType},
+		"market price":   {&binance.SOROrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1", Price: "1"}, binance.ErrForbiddenOrderParam},
+		"market iceberg": {&binance.SOROrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1", IcebergQty: "1"}, binance.ErrForbiddenOrderParam},
+	} {
+		s.Require().ErrorIs(s.api.NewSOROrderTest(tc.req), tc.err, name)
+	}
+}
+
+func (s *mockedTestSuite) TestAllocations() {
+	expected := []*binance.Allocation{{
+		Symbol:          "BTCUSDT",
+		AllocationID:    0,
+		AllocationType:  binance.AllocationTypeSOR,
+		OrderID:         500,
+		OrderListID:     -1,
+		Price:           mustDecimal("1.00000000"),
+		Qty:             mustDecimal("0.10000000"),
+		QuoteQty:        mustDecimal("0.10000000"),
+		Commission:      mustDecimal("0.00000000"),
+		CommissionAsset: "BTC",
+		Time:            1687506878118,
+		Buyer:           true,
+		Allocator:       true,
+	}}
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointAllocations, endpoint)
+		s.Require().Equal(binance.DefaultOrderLimit, data.(*binance.AllocationsReq).Limit)
+
+		return json.Marshal(expected)
+	}
+	actual, err := s.api.Allocations(&binance.AllocationsReq{Symbol: "BTCUSDT", OrderID: 500})
+	s.Require().NoError(err)
+	s.requireEqualJSON(expected, actual)
+
+	_, err = s.api.Allocations(&binance.AllocationsReq{})
+	s.Require().ErrorIs(err, binance.ErrEmptySymbol)
+	_, err = s.api.Allocations(&binance.AllocationsReq{Symbol: "BTCUSDT", FromAllocationID: 1, EndTime: 1})
+	s.Require().ErrorIs(err, binance.ErrInvalidTimeRange)
+}
+
+func (s *mockedTestSuite) TestExchangeInfoSORs() {
+	info := &binance.ExchangeInfo{}
+	s.Require().NoError(json.Unmarshal([]byte(`{"symbols":[],"sors":[{"baseAsset":"BTC","symbols":["BTCUSDT","BTCUSDC"]}]}`), info))
+	sor, ok := info.SOR("BTCUSDC")
+	s.Require().True(ok)
+	s.Require().Equal("BTC", sor.BaseAsset)
+	_, ok = info.SOR("ETHUSDT")
+	s.Require().False(ok)
+}
@@ -0,0 +1,81 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+// SelfTradePreventionMode defines what happens when an order would match an order of the same account or trade group
+type SelfTradePreventionMode string
+
+const (
+	SelfTradePreventionModeNone        SelfTradePreventionMode = "NONE"
+	SelfTradePreventionModeExpireTaker SelfTradePreventionMode = "EXPIRE_TAKER"
+	SelfTradePreventionModeExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
+	SelfTradePreventionModeExpireBoth  SelfTradePreventionMode = "EXPIRE_BOTH"
+	SelfTradePreventionModeDecrement   SelfTradePreventionMode = "DECREMENT"
+	SelfTradePreventionModeTransfer    SelfTradePreventionMode = "TRANSFER"
+)
+
+// SelfTradePreventionModeAllowed reports whether the mode can be used for the symbol orders.
+// Empty mode is always allowed, the symbol default one is used then
+func (s *SymbolInfo) SelfTradePreventionModeAllowed(mode SelfTradePreventionMode) bool {
+	if mode == "" {
+		return true
+	}
+	for _, m := range s.AllowedSelfTradePreventionModes {
+		if m == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PreventedMatchesReq represents the request used for querying orders expired by self-trade prevention
+// Remark: Either PreventedMatchID or OrderID must be set, FromPreventedMatchID can be used only with OrderID
+type PreventedMatchesReq struct {
+	Symbol               string `url:"symbol"`
+	PreventedMatchID     int64  `url:"preventedMatchId,omitempty"`
+	OrderID              uint64 `url:"orderId,omitempty"`
+	FromPreventedMatchID int64  `url:"fromPreventedMatchId,omitempty"`
+	Limit                int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+type PreventedMatch struct {
+	Symbol                  string                  `json:"symbol"`
+	PreventedMatchID        int64                   `json:"preventedMatchId"`
+	TakerOrderID            uint64                  `json:"takerOrderId"`
+	MakerSymbol             string                  `json:"makerSymbol"`
+	MakerOrderID            uint64                  `json:"makerOrderId"`
+	TradeGroupID            int64                   `json:"tradeGroupId"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	Price                   decimal.Decimal         `json:"price"`
+	MakerPreventedQuantity  decimal.Decimal         `json:"makerPreventedQuantity"`
+	TransactTime            uint64                  `json:"transactTime"`
+}
+
+// PreventedMatches get orders expired by self-trade prevention
+func (c *Client) PreventedMatches(req *PreventedMatchesReq) ([]*PreventedMatch, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.PreventedMatchID == 0 && req.OrderID == 0 {
+		return nil, ErrEmptyOrderID
+	}
+	if req.Limit <= 0 || req.Limit > MaxOrderLimit {
+		req.Limit = DefaultOrderLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointPreventedMatches, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*PreventedMatch
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
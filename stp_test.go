@@ -0,0 +1,63 @@
+package binance_test
+
+import (
+	"github.com/google/go-querystring/query"
+	"github.com/segmentio/encoding/json"
+
+	"github.com/ugi1/binance-api"
+)
+
+func (s *mockedTestSuite) TestPreventedMatches() {
+	expected := []*binance.PreventedMatch{{
+		Symbol:                  "BTCUSDT",
+		PreventedMatchID:        1,
+		TakerOrderID:            5,
+		MakerSymbol:             "BTCUSDT",
+		MakerOrderID:            3,
+		TradeGroupID:            1,
+		SelfTradePreventionMode: binance.SelfTradePreventionModeExpireMaker,
+		Price:                   mustDecimal("1.100000"),
+		MakerPreventedQuantity:  mustDecimal("1.300000"),
+		TransactTime:            1669101687094,
+	}}
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointPreventedMatches, endpoint)
+		s.Require().True(sign)
+
+		return json.Marshal(expected)
+	}
+	actual, err := s.api.PreventedMatches(&binance.PreventedMatchesReq{Symbol: "BTCUSDT", OrderID: 5})
+	s.Require().NoError(err)
+	s.requireEqualJSON(expected, actual)
+
+	_, err = s.api.PreventedMatches(&binance.PreventedMatchesReq{Symbol: "BTCUSDT"})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderID)
+}
+
+func (s *mockedTestSuite) TestSelfTradePreventionFields() {
+	values, err := query.Values(&binance.OrderReq{
+		Symbol:                  "BTCUSDT",
+		Side:                    binance.OrderSideBuy,
+		Type:                    binance.OrderTypeMarket,
+		Quantity:                "1",
+		SelfTradePreventionMode: binance.SelfTradePreventionModeExpireBoth,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("EXPIRE_BOTH", values.Get("selfTradePreventionMode"))
+
+	order := &binance.QueryOrder{}
+	s.Require().NoError(json.Unmarshal([]byte(`{"symbol":"BTCUSDT","orderId":5,"status":"EXPIRED_IN_MATCH",
+		"selfTradePreventionMode":"EXPIRE_MAKER","preventedMatchId":1,"preventedQuantity":"1.200000"}`), order))
+	s.Require().Equal(binance.OrderStatusExpiredInMatch, order.Status)
+	s.Require().Equal(binance.SelfTradePreventionModeExpireMaker, order.SelfTradePreventionMode)
+	s.Require().EqualValues(1, order.PreventedMatchID)
+	s.Require().Equal("1.200000", binance.FormatDecimal(order.PreventedQuantity))
+
+	info := &binance.SymbolInfo{}
+	s.Require().NoError(json.Unmarshal([]byte(`{"symbol":"BTCUSDT","defaultSelfTradePreventionMode":"NONE",
+		"allowedSelfTradePreventionModes":["NONE","EXPIRE_TAKER","EXPIRE_BOTH"]}`), info))
+	s.Require().Equal(binance.SelfTradePreventionModeNone, info.DefaultSelfTradePreventionMode)
+	s.Require().True(info.SelfTradePreventionModeAllowed(""))
+	s.Require().True(info.SelfTradePreventionModeAllowed(binance.SelfTradePreventionModeExpireTaker))
+	s.Require().False(info.SelfTradePreventionModeAllowed(binance.SelfTradePreventionModeDecrement))
+}
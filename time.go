@@ -0,0 +1,159 @@
+package binance
+
+import (
+	"time"
+)
+
+const week = 7 * 24 * time.Hour
+
+// MsToTime converts Binance millisecond timestamp to UTC time, zero timestamp is converted to zero time
+func MsToTime(ms uint64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(int64(ms)).UTC()
+}
+
+// TimeToMs converts time to Binance millisecond timestamp, zero time is converted to zero timestamp
+func TimeToMs(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return uint64(t.UnixMilli())
+}
+
+// Time returns server time as time.Time
+func (t *ServerTime) Time() time.Time {
+	return MsToTime(t.ServerTime)
+}
+
+// OpenAt returns the kline open time as time.Time
+func (k *Klines) OpenAt() time.Time {
+	return MsToTime(k.OpenTime)
+}
+
+// CloseAt returns the kline close time as time.Time
+func (k *Klines) CloseAt() time.Time {
+	return MsToTime(k.CloseTime)
+}
+
+// CreatedAt returns the order creation time as time.Time
+func (o *QueryOrder) CreatedAt() time.Time {
+	return MsToTime(o.Time)
+}
+
+// UpdatedAt returns the last order update time as time.Time
+func (o *QueryOrder) UpdatedAt() time.Time {
+	return MsToTime(o.UpdateTime)
+}
+
+var klineIntervalDurations = map[KlineInterval]time.Duration{
+	KlineInterval1sec:   time.Second,
+	KlineInterval1min:   time.Minute,
+	KlineInterval3min:   3 * time.Minute,
+	KlineInterval5min:   5 * time.Minute,
+	KlineInterval15min:  15 * time.Minute,
+	KlineInterval30min:  30 * time.Minute,
+	KlineInterval1hour:  time.Hour,
+	KlineInterval2hour:  2 * time.Hour,
+	KlineInterval4hour:  4 * time.Hour,
+	KlineInterval6hour:  6 * time.Hour,
+	KlineInterval8hour:  8 * time.Hour,
+	KlineInterval12hour: 12 * time.Hour,
+	KlineInterval1day:   24 * time.Hour,
+	KlineInterval3day:   3 * 24 * time.Hour,
+	KlineInterval1week:  week,
+	KlineInterval1month: 30 * 24 * time.Hour,
+}
+
+// ParseKlineInterval parses interval in Binance notation like "15m" or "1M"
+func ParseKlineInterval(s string) (KlineInterval, error) {
+	i := KlineInterval(s)
+	if !i.Valid() {
+		return "", ErrInvalidKlineInterval
+	}
+
+	return i, nil
+}
+
+// Valid reports whether the interval is supported by Binance
+func (i KlineInterval) Valid() bool {
+	_, ok := klineIntervalDurations[i]
+
+	return ok
+}
+
+// Calendar reports whether the interval length depends on the calendar, it's true only for 1M
+func (i KlineInterval) Calendar() bool {
+	return i == KlineInterval1month
+}
+
+// Duration returns the interval length or zero for unknown intervals.
+// 1M is reported as 30 days, use Truncate and Next to get exact month boundaries
+func (i KlineInterval) Duration() time.Duration {
+	return klineIntervalDurations[i]
+}
+
+// Truncate returns the open time of the interval containing t in UTC.
+// Intervals up to 3d are aligned to multiples of the interval since Unix epoch,
+// 1w starts on Monday and 1M starts on the first day of the month.
+// It returns t in UTC for unknown intervals
+func (i KlineInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch i { //nolint:exhaustive
+	case KlineInterval1week:
+		day := t.Truncate(24 * time.Hour)
+		offset := (int(day.Weekday()) + 6) % 7
+
+		return day.AddDate(0, 0, -offset)
+	case KlineInterval1month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	d := i.Duration().Milliseconds()
+	if d == 0 {
+		return t
+	}
+	// time.Truncate aligns to the zero time, which isn't a multiple of 3d since Unix epoch
+	ms := t.UnixMilli()
+	rem := ms % d
+	if rem < 0 {
+		rem += d
+	}
+
+	return time.UnixMilli(ms - rem).UTC()
+}
+
+// Next returns the open time of the interval following the one containing t
+func (i KlineInterval) Next(t time.Time) time.Time {
+	start := i.Truncate(t)
+	switch i { //nolint:exhaustive
+	case KlineInterval1month:
+		return start.AddDate(0, 1, 0)
+	case KlineInterval1week:
+		return start.AddDate(0, 0, 7)
+	}
+
+	return start.Add(i.Duration())
+}
+
+// SetTimeRange sets StartTime and EndTime from time values, zero time leaves the bound unset
+func (r *KlinesReq) SetTimeRange(start, end time.Time) {
+	r.StartTime, r.EndTime = TimeToMs(start), TimeToMs(end)
+}
+
+// SetTimeRange sets StartTime and EndTime from time values, zero time leaves the bound unset
+func (r *AggregatedTradeReq) SetTimeRange(start, end time.Time) {
+	r.StartTime, r.EndTime = TimeToMs(start), TimeToMs(end)
+}
+
+// SetTimeRange sets StartTime and EndTime from time values, zero time leaves the bound unset
+func (r *AllOrdersReq) SetTimeRange(start, end time.Time) {
+	r.StartTime, r.EndTime = TimeToMs(start), TimeToMs(end)
+}
+
+// SetTimeRange sets StartTime and EndTime from time values, zero time leaves the bound unset
+func (r *AccountTradesReq) SetTimeRange(start, end time.Time) {
+	r.StartTime, r.EndTime = TimeToMs(start), TimeToMs(end)
+}
@@ -0,0 +1,60 @@
+package binance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ugi1/binance-api"
+)
+
+func TestMsToTime(t *testing.T) {
+	ts := time.Date(2023, 11, 14, 22, 13, 20, 123e6, time.UTC)
+	require.Equal(t, uint64(1700000000123), binance.TimeToMs(ts))
+	require.Equal(t, ts, binance.MsToTime(1700000000123))
+	require.True(t, binance.MsToTime(0).IsZero())
+	require.Zero(t, binance.TimeToMs(time.Time{}))
+
+	req := &binance.KlinesReq{}
+	req.SetTimeRange(ts, time.Time{})
+	require.Equal(t, uint64(1700000000123), req.StartTime)
+	require.Zero(t, req.EndTime)
+
+	k := &binance.Klines{OpenTime: 1700000000123, CloseTime: 1700000059999}
+	require.Equal(t, ts, k.OpenAt())
+	require.Equal(t, ts.Add(time.Minute-124*time.Millisecond), k.CloseAt())
+	o := &binance.QueryOrder{Time: 1700000000123}
+	require.Equal(t, ts, o.CreatedAt())
+	require.True(t, o.UpdatedAt().IsZero())
+}
+
+func TestKlineInterval(t *testing.T) {
+	i, err := binance.ParseKlineInterval("15m")
+	require.NoError(t, err)
+	require.Equal(t, binance.KlineInterval15min, i)
+	require.Equal(t, 15*time.Minute, i.Duration())
+	_, err = binance.ParseKlineInterval("2m")
+	require.ErrorIs(t, err, binance.ErrInvalidKlineInterval)
+	require.True(t, binance.KlineInterval1month.Calendar())
+	require.False(t, binance.KlineInterval1week.Calendar())
+
+	// Thursday
+	ts := time.Date(2024, 2, 29, 13, 47, 12, 0, time.UTC)
+	for _, c := range []struct {
+		interval   binance.KlineInterval
+		start, end time.Time
+	}{
+		{binance.KlineInterval15min, time.Date(2024, 2, 29, 13, 45, 0, 0, time.UTC), time.Date(2024, 2, 29, 14, 0, 0, 0, time.UTC)},
+		{binance.KlineInterval4hour, time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 16, 0, 0, 0, time.UTC)},
+		{binance.KlineInterval3day, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{binance.KlineInterval1week, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{binance.KlineInterval1month, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		require.Equal(t, c.start, c.interval.Truncate(ts), c.interval)
+		require.Equal(t, c.end, c.interval.Next(ts), c.interval)
+		require.Equal(t, c.start, c.interval.Truncate(c.start), c.interval)
+	}
+	// 3d intervals are multiples of 3 days since epoch
+	require.Zero(t, binance.TimeToMs(binance.KlineInterval3day.Truncate(ts))%uint64(3*24*time.Hour/time.Millisecond))
+}
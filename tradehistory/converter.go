@@ -0,0 +1,81 @@
+package tradehistory
+
+import (
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+const minuteMs = 60 * 1000
+
+type klineKey struct {
+	symbol string
+	minute uint64
+}
+
+// KlinesConverter converts assets at the close price of the 1 minute kline of the trade time.
+// Only direct and inverse symbols are used, prices are cached
+type KlinesConverter struct {
+	client  *binance.Client
+	symbols map[string]bool
+	cache   map[klineKey]decimal.Decimal
+}
+
+// NewKlinesConverter creates a converter of assets traded on the exchange
+func NewKlinesConverter(client *binance.Client, info *binance.ExchangeInfo) *KlinesConverter {
+	c := &KlinesConverter{
+		client:  client,
+		symbols: make(map[string]bool, len(info.Symbols)),
+		cache:   make(map[klineKey]decimal.Decimal),
+	}
+	for i := range info.Symbols {
+		c.symbols[info.Symbols[i].Symbol] = true
+	}
+
+	return c
+}
+
+// Price returns the price of the asset in the quote asset at the time in ms
+func (c *KlinesConverter) Price(asset, quote string, time uint64) (decimal.Decimal, error) {
+	if asset == quote {
+		return decimal.New(1, 0), nil
+	}
+	minute := time - time%minuteMs
+	if c.symbols[asset+quote] {
+		return c.close(asset+quote, minute)
+	}
+	if c.symbols[quote+asset] {
+		price, err := c.close(quote+asset, minute)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		return decimal.New(1, 0).Div(price), nil
+	}
+
+	return decimal.Zero, errors.Wrapf(ErrNoPrice, "%s in %s", asset, quote)
+}
+
+func (c *KlinesConverter) close(symbol string, minute uint64) (decimal.Decimal, error) {
+	key := klineKey{symbol, minute}
+	if price, ok := c.cache[key]; ok {
+		return price, nil
+	}
+	klines, err := c.client.Klines(&binance.KlinesReq{
+		Symbol:    symbol,
+		Interval:  binance.KlineInterval1min,
+		Limit:     1,
+		StartTime: minute,
+		EndTime:   minute + minuteMs - 1,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if len(klines) == 0 || klines[0].ClosePrice.IsZero() {
+		return decimal.Zero, errors.Wrapf(ErrNoPrice, "%s at %d", symbol, minute)
+	}
+	c.cache[key] = klines[0].ClosePrice
+
+	return klines[0].ClosePrice, nil
+}
@@ -0,0 +1,374 @@
+// Package tradehistory fetches account trades and accounts realised and unrealised PnL of positions.
+//
+// Positions are kept per symbol in the base asset and valued in the quote asset. Buys open lots and
+// sells close them either first in first out or against the average cost. Commissions are fees in the
+// quote asset, the ones paid in another asset, e.g. BNB, are converted by Converter.
+//
+//	trades, err := tradehistory.Fetch(client, []string{"BTCUSDT", "ETHUSDT"}, start, end)
+//	book := tradehistory.NewBook(tradehistory.MethodFIFO, info, tradehistory.NewKlinesConverter(client, info))
+//	if err = book.AddAll(trades); err != nil {
+//		return err
+//	}
+//	for _, p := range book.Positions() {
+//		fmt.Println(p.Symbol, p.Realized, p.Unrealized(prices[p.Symbol]))
+//	}
+package tradehistory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+var (
+	ErrUnknownSymbol = errors.New("symbol isn't in exchange info")
+	ErrNoPrice       = errors.New("no price to convert the asset")
+)
+
+// Fetch returns account trades of the symbols in [start, end] sorted by time.
+// Trades of every symbol are paged by trade id, zero end means until now.
+//
+// Remark: the first trade of every symbol is located by up to 24 hours windows with request weight 20 each,
+// so a long range without trades costs a request per day. Use FetchFrom when the first trade IDs are known
+func Fetch(client *binance.Client, symbols []string, start, end time.Time) ([]*binance.AccountTrades, error) {
+	reqs := make([]*binance.AccountTradesReq, 0, len(symbols))
+	for _, symbol := range symbols {
+		req := &binance.AccountTradesReq{Symbol: symbol}
+		req.SetTimeRange(start, end)
+		reqs = append(reqs, req)
+	}
+
+	return fetch(client, reqs)
+}
+
+// FetchFrom returns account trades of the symbols starting from the trade IDs sorted by time,
+// e.g. from Position.LastTradeID+1 to add new trades to a book. Zero end means until now
+func FetchFrom(client *binance.Client, fromIDs map[string]int64, end time.Time) ([]*binance.AccountTrades, error) {
+	symbols := make([]string, 0, len(fromIDs))
+	for symbol := range fromIDs {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	reqs := make([]*binance.AccountTradesReq, 0, len(symbols))
+	for _, symbol := range symbols {
+		req := (&binance.AccountTradesReq{Symbol: symbol}).WithFromID(fromIDs[symbol])
+		req.EndTime = binance.TimeToMs(end)
+		reqs = append(reqs, req)
+	}
+
+	return fetch(client, reqs)
+}
+
+func fetch(client *binance.Client, reqs []*binance.AccountTradesReq) ([]*binance.AccountTrades, error) {
+	var res []*binance.AccountTrades
+	for _, req := range reqs {
+		trades, err := client.AccountTradesRange(req).All()
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch %s trades", req.Symbol)
+		}
+		res = append(res, trades...)
+	}
+	sortTrades(res)
+
+	return res, nil
+}
+
+func sortTrades(trades []*binance.AccountTrades) {
+	sort.SliceStable(trades, func(i, j int) bool {
+		a, b := trades[i], trades[j]
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		if a.Symbol != b.Symbol {
+			return a.Symbol < b.Symbol
+		}
+
+		return a.ID < b.ID
+	})
+}
+
+// Method represents the way sold quantity is matched with bought lots
+type Method string
+
+const (
+	MethodFIFO        Method = "FIFO"         // MethodFIFO sells the oldest lots first
+	MethodAverageCost Method = "AVERAGE_COST" // MethodAverageCost sells at the average cost of all lots
+)
+
+// Converter returns the price of the asset in the quote asset at the time in ms
+type Converter interface {
+	Price(asset, quote string, time uint64) (decimal.Decimal, error)
+}
+
+// Prices is a converter using fixed prices by symbol, e.g. "BNBUSDT", inverse symbols are used too
+type Prices map[string]decimal.Decimal
+
+// Price returns the fixed price of the asset in the quote asset
+func (p Prices) Price(asset, quote string, _ uint64) (decimal.Decimal, error) {
+	if asset == quote {
+		return decimal.New(1, 0), nil
+	}
+	if price, ok := p[asset+quote]; ok {
+		return price, nil
+	}
+	if price, ok := p[quote+asset]; ok && !price.IsZero() {
+		return decimal.New(1, 0).Div(price), nil
+	}
+
+	return decimal.Zero, errors.Wrapf(ErrNoPrice, "%s in %s", asset, quote)
+}
+
+type lot struct {
+	qty  decimal.Decimal
+	cost decimal.Decimal
+}
+
+// Position is the result of trades of a single symbol
+type Position struct {
+	Symbol       string
+	BaseAsset    string
+	QuoteAsset   string
+	Qty          decimal.Decimal // Qty is the open base asset quantity
+	Cost         decimal.Decimal // Cost is the quote asset cost of the open quantity including buy fees
+	Realized     decimal.Decimal // Realized is the quote asset PnL of closed quantity net of fees
+	Fees         decimal.Decimal // Fees are all commissions in the quote asset
+	UnmatchedQty decimal.Decimal // UnmatchedQty is the sold quantity without known buys, it is realised at zero cost
+	Commissions  map[string]decimal.Decimal
+	LastTradeID  int64
+
+	lots []lot
+}
+
+// AvgCost returns the average cost of the open quantity
+func (p *Position) AvgCost() decimal.Decimal {
+	if p.Qty.IsZero() {
+		return decimal.Zero
+	}
+
+	return p.Cost.Div(p.Qty)
+}
+
+// Unrealized returns PnL of the open quantity at the price
+func (p *Position) Unrealized(price decimal.Decimal) decimal.Decimal {
+	return p.Qty.Mul(price).Sub(p.Cost)
+}
+
+// PnL is the PnL of all positions valued in the asset
+type PnL struct {
+	Asset      string
+	Realized   decimal.Decimal
+	Unrealized decimal.Decimal
+	Fees       decimal.Decimal
+}
+
+// Book accounts trades of many symbols
+type Book struct {
+	method    Method
+	converter Converter
+	symbols   map[string]*binance.SymbolInfo
+	positions map[string]*Position
+}
+
+// NewBook creates an empty book, the converter is needed only for commissions paid in a third asset
+func NewBook(method Method, info *binance.ExchangeInfo, converter Converter) *Book {
+	b := &Book{
+		method:    method,
+		converter: converter,
+		symbols:   make(map[string]*binance.SymbolInfo, len(info.Symbols)),
+		positions: make(map[string]*Position),
+	}
+	for i := range info.Symbols {
+		b.symbols[info.Symbols[i].Symbol] = &info.Symbols[i]
+	}
+
+	return b
+}
+
+// AddAll adds trades in time order
+func (b *Book) AddAll(trades []*binance.AccountTrades) error {
+	sorted := append([]*binance.AccountTrades(nil), trades...)
+	sortTrades(sorted)
+	for _, t := range sorted {
+		if err := b.Add(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Add accounts the trade, trades of a symbol must be added in id order.
+// Trades already added are skipped, so overlapping pages can be added safely
+func (b *Book) Add(t *binance.AccountTrades) error {
+	p, err := b.position(t.Symbol)
+	if err != nil {
+		return err
+	}
+	if t.ID <= p.LastTradeID {
+		return nil
+	}
+	fee, feeQty := decimal.Zero, decimal.Zero
+	if !t.Commission.IsZero() {
+		switch t.CommissionAsset {
+		case p.QuoteAsset:
+			fee = t.Commission
+		case p.BaseAsset:
+			// commission in the base asset reduces the received or increases the sold quantity
+			feeQty = t.Commission
+			fee = t.Commission.Mul(t.Price)
+		default:
+			if b.converter == nil {
+				return errors.Wrapf(ErrNoPrice, "%s in %s", t.CommissionAsset, p.QuoteAsset)
+			}
+			price, err := b.converter.Price(t.CommissionAsset, p.QuoteAsset, t.Time)
+			if err != nil {
+				return err
+			}
+			fee = t.Commission.Mul(price)
+		}
+		p.Commissions[t.CommissionAsset] = p.Commissions[t.CommissionAsset].Add(t.Commission)
+		p.Fees = p.Fees.Add(fee)
+	}
+	quoteQty := t.QuoteQty
+	if quoteQty.IsZero() {
+		quoteQty = t.Qty.Mul(t.Price)
+	}
+	if t.Buyer {
+		cost := quoteQty
+		if feeQty.IsZero() {
+			cost = cost.Add(fee)
+		}
+		p.open(t.Qty.Sub(feeQty), cost, b.method)
+	} else {
+		proceeds := quoteQty
+		if feeQty.IsZero() {
+			proceeds = proceeds.Sub(fee)
+		}
+		p.Realized = p.Realized.Add(proceeds.Sub(p.close(t.Qty.Add(feeQty))))
+	}
+	p.LastTradeID = t.ID
+
+	return nil
+}
+
+// Position returns the position of the symbol
+func (b *Book) Position(symbol string) (Position, bool) {
+	p, ok := b.positions[symbol]
+	if !ok {
+		return Position{}, false
+	}
+
+	return p.clone(), true
+}
+
+// Positions returns all positions sorted by symbol
+func (b *Book) Positions() []Position {
+	res := make([]Position, 0, len(b.positions))
+	for _, p := range b.positions {
+		res = append(res, p.clone())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Symbol < res[j].Symbol })
+
+	return res
+}
+
+// PnL sums PnL of positions by quote asset, prices are the current prices by symbol.
+// Positions without the price have zero unrealised PnL
+func (b *Book) PnL(prices map[string]decimal.Decimal) []PnL {
+	byAsset := make(map[string]*PnL)
+	for _, p := range b.positions {
+		res, ok := byAsset[p.QuoteAsset]
+		if !ok {
+			res = &PnL{Asset: p.QuoteAsset}
+			byAsset[p.QuoteAsset] = res
+		}
+		res.Realized = res.Realized.Add(p.Realized)
+		res.Fees = res.Fees.Add(p.Fees)
+		if price, ok := prices[p.Symbol]; ok {
+			res.Unrealized = res.Unrealized.Add(p.Unrealized(price))
+		}
+	}
+	res := make([]PnL, 0, len(byAsset))
+	for _, v := range byAsset {
+		res = append(res, *v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Asset < res[j].Asset })
+
+	return res
+}
+
+func (b *Book) position(symbol string) (*Position, error) {
+	if p, ok := b.positions[symbol]; ok {
+		return p, nil
+	}
+	info, ok := b.symbols[symbol]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownSymbol, "%s", symbol)
+	}
+	p := &Position{
+		Symbol:      symbol,
+		BaseAsset:   info.BaseAsset,
+		QuoteAsset:  info.QuoteAsset,
+		Commissions: make(map[string]decimal.Decimal),
+	}
+	b.positions[symbol] = p
+
+	return p, nil
+}
+
+func (p *Position) clone() Position {
+	c := *p
+	c.lots = nil
+	c.Commissions = make(map[string]decimal.Decimal, len(p.Commissions))
+	for asset, v := range p.Commissions {
+		c.Commissions[asset] = v
+	}
+
+	return c
+}
+
+// open adds bought quantity, the average cost method keeps a single lot
+func (p *Position) open(qty, cost decimal.Decimal, method Method) {
+	p.Qty = p.Qty.Add(qty)
+	p.Cost = p.Cost.Add(cost)
+	if method == MethodAverageCost {
+		p.lots = []lot{{qty: p.Qty, cost: p.Cost}}
+
+		return
+	}
+	p.lots = append(p.lots, lot{qty: qty, cost: cost})
+}
+
+// close removes sold quantity from the oldest lots and returns its cost
+func (p *Position) close(qty decimal.Decimal) decimal.Decimal {
+	cost := decimal.Zero
+	for qty.IsPositive() && len(p.lots) > 0 {
+		l := &p.lots[0]
+		if qty.LessThan(l.qty) {
+			part := l.cost.Mul(qty).Div(l.qty)
+			cost = cost.Add(part)
+			l.cost = l.cost.Sub(part)
+			l.qty = l.qty.Sub(qty)
+			qty = decimal.Zero
+
+			break
+		}
+		cost = cost.Add(l.cost)
+		qty = qty.Sub(l.qty)
+		p.lots = p.lots[1:]
+	}
+	if qty.IsPositive() {
+		p.UnmatchedQty = p.UnmatchedQty.Add(qty)
+	}
+	p.Qty, p.Cost = decimal.Zero, decimal.Zero
+	for _, l := range p.lots {
+		p.Qty = p.Qty.Add(l.qty)
+		p.Cost = p.Cost.Add(l.cost)
+	}
+
+	return cost
+}
@@ -0,0 +1,252 @@
+package tradehistory_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+	"github.com/ugi1/binance-api/tradehistory"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func requireDec(t *testing.T, expected string, actual decimal.Decimal) {
+	t.Helper()
+	require.True(t, dec(expected).Equal(actual), "expected %s, got %s", expected, actual)
+}
+
+// historyClient serves account trades and 1 minute klines with a fixed close price
+type historyClient struct {
+	trades []*binance.AccountTrades
+	closes map[string]string
+	klines int
+}
+
+func (c *historyClient) Do(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+	switch req := data.(type) {
+	case *binance.AccountTradesReq:
+		res := []*binance.AccountTrades{}
+		for _, t := range c.trades {
+			if t.Symbol != req.Symbol || len(res) == req.Limit {
+				continue
+			}
+			if req.FromID != nil && t.ID >= *req.FromID ||
+				req.FromID == nil && t.Time >= req.StartTime && t.Time <= req.EndTime {
+				res = append(res, t)
+			}
+		}
+
+		return json.Marshal(res)
+	case *binance.KlinesReq:
+		c.klines++
+		price, ok := c.closes[req.Symbol]
+		if !ok {
+			return []byte("[]"), nil
+		}
+
+		return []byte(fmt.Sprintf(`[[%d,"1","1","1","%s","1",%d,"1",1,"1","1","0"]]`,
+			req.StartTime, price, req.StartTime+59999)), nil
+	}
+	panic("unexpected request " + endpoint)
+}
+
+func (c *historyClient) SetWindow(int)                {}
+func (c *historyClient) UsedWeight() map[string]int64 { return nil }
+func (c *historyClient) OrderCount() map[string]int64 { return nil }
+func (c *historyClient) RetryAfter() int64            { return 0 }
+
+var info = &binance.ExchangeInfo{
+	Symbols: []binance.SymbolInfo{
+		{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"},
+		{Symbol: "ETHBTC", BaseAsset: "ETH", QuoteAsset: "BTC"},
+		{Symbol: "BNBUSDT", BaseAsset: "BNB", QuoteAsset: "USDT"},
+		{Symbol: "BNBBTC", BaseAsset: "BNB", QuoteAsset: "BTC"},
+	},
+}
+
+func trade(id int64, symbol string, buy bool, qty, price string) *binance.AccountTrades {
+	return &binance.AccountTrades{
+		ID:     id,
+		Symbol: symbol,
+		Qty:    dec(qty),
+		Price:  dec(price),
+		Time:   uint64(1000 + id),
+		Buyer:  buy,
+	}
+}
+
+func withCommission(t *binance.AccountTrades, commission, asset string) *binance.AccountTrades {
+	t.Commission = dec(commission)
+	t.CommissionAsset = asset
+
+	return t
+}
+
+func TestBookMethods(t *testing.T) {
+	trades := []*binance.AccountTrades{
+		trade(1, "BTCUSDT", true, "1", "100"),
+		trade(2, "BTCUSDT", true, "1", "200"),
+		trade(3, "BTCUSDT", false, "1", "250"),
+	}
+
+	fifo := tradehistory.NewBook(tradehistory.MethodFIFO, info, nil)
+	require.NoError(t, fifo.AddAll(trades))
+	p, ok := fifo.Position("BTCUSDT")
+	require.True(t, ok)
+	requireDec(t, "150", p.Realized)
+	requireDec(t, "1", p.Qty)
+	requireDec(t, "200", p.Cost)
+	requireDec(t, "100", p.Unrealized(dec("300")))
+
+	avg := tradehistory.NewBook(tradehistory.MethodAverageCost, info, nil)
+	require.NoError(t, avg.AddAll(trades))
+	p, ok = avg.Position("BTCUSDT")
+	require.True(t, ok)
+	requireDec(t, "100", p.Realized)
+	requireDec(t, "1", p.Qty)
+	requireDec(t, "150", p.AvgCost())
+	requireDec(t, "150", p.Unrealized(dec("300")))
+
+	_, ok = avg.Position("ETHBTC")
+	require.False(t, ok)
+}
+
+func TestBookCommissions(t *testing.T) {
+	book := tradehistory.NewBook(tradehistory.MethodFIFO, info, tradehistory.Prices{"BNBUSDT": dec("300")})
+	require.NoError(t, book.AddAll([]*binance.AccountTrades{
+		// the quote asset commission is added to the cost
+		withCommission(trade(1, "BTCUSDT", true, "1", "100"), "1", "USDT"),
+		// the base asset commission reduces the received quantity
+		withCommission(trade(2, "BTCUSDT", true, "1", "100"), "0.01", "BTC"),
+		// BNB commission is converted into the quote asset
+		withCommission(trade(3, "BTCUSDT", false, "1", "150"), "0.01", "BNB"),
+	}))
+	p, _ := book.Position("BTCUSDT")
+	requireDec(t, "0.99", p.Qty)
+	requireDec(t, "100", p.Cost)
+	requireDec(t, "5", p.Fees)
+	requireDec(t, "46", p.Realized)
+	requireDec(t, "1", p.Commissions["USDT"])
+	requireDec(t, "0.01", p.Commissions["BTC"])
+	requireDec(t, "0.01", p.Commissions["BNB"])
+
+	// USDT has no price in BTC
+	err := book.Add(withCommission(trade(4, "ETHBTC", true, "1", "0.05"), "0.001", "USDT"))
+	require.ErrorIs(t, err, tradehistory.ErrNoPrice)
+	err = book.Add(trade(5, "ETHUSDT", true, "1", "1000"))
+	require.ErrorIs(t, err, tradehistory.ErrUnknownSymbol)
+
+	// inverse symbol price
+	require.NoError(t, tradehistory.NewBook(tradehistory.MethodFIFO, info, tradehistory.Prices{"BTCBNB": dec("100")}).
+		Add(withCommission(trade(6, "ETHBTC", true, "1", "0.05"), "1", "BNB")))
+}
+
+func TestBookDuplicatesAndUnmatched(t *testing.T) {
+	book := tradehistory.NewBook(tradehistory.MethodFIFO, info, nil)
+	require.NoError(t, book.Add(trade(1, "BTCUSDT", true, "1", "100")))
+	require.NoError(t, book.Add(trade(2, "BTCUSDT", false, "2", "150")))
+	// overlapping page
+	require.NoError(t, book.AddAll([]*binance.AccountTrades{
+		trade(1, "BTCUSDT", true, "1", "100"),
+		trade(2, "BTCUSDT", false, "2", "150"),
+	}))
+	p, _ := book.Position("BTCUSDT")
+	require.Equal(t, int64(2), p.LastTradeID)
+	requireDec(t, "0", p.Qty)
+	requireDec(t, "1", p.UnmatchedQty)
+	requireDec(t, "200", p.Realized)
+}
+
+func TestBookPnL(t *testing.T) {
+	book := tradehistory.NewBook(tradehistory.MethodFIFO, info, nil)
+	require.NoError(t, book.AddAll([]*binance.AccountTrades{
+		trade(1, "BTCUSDT", true, "2", "100"),
+		trade(2, "BNBUSDT", true, "10", "10"),
+		trade(3, "ETHBTC", true, "1", "0.05"),
+		trade(4, "BTCUSDT", false, "1", "120"),
+	}))
+	pnl := book.PnL(map[string]decimal.Decimal{"BTCUSDT": dec("130"), "ETHBTC": dec("0.06")})
+	require.Len(t, pnl, 2)
+	require.Equal(t, "BTC", pnl[0].Asset)
+	requireDec(t, "0", pnl[0].Realized)
+	requireDec(t, "0.01", pnl[0].Unrealized)
+	require.Equal(t, "USDT", pnl[1].Asset)
+	requireDec(t, "20", pnl[1].Realized)
+	// BNBUSDT has no price
+	requireDec(t, "30", pnl[1].Unrealized)
+
+	positions := book.Positions()
+	require.Len(t, positions, 3)
+	require.Equal(t, "BNBUSDT", positions[0].Symbol)
+	require.Equal(t, "ETHBTC", positions[2].Symbol)
+}
+
+func TestFetch(t *testing.T) {
+	mock := &historyClient{}
+	for i := int64(1); i <= 1200; i++ {
+		symbol := "BTCUSDT"
+		if i%3 == 0 {
+			symbol = "ETHBTC"
+		}
+		mock.trades = append(mock.trades, trade(i, symbol, i%2 == 0, "1", "1"))
+	}
+	client := binance.NewCustomClient(mock)
+
+	trades, err := tradehistory.Fetch(client, []string{"ETHBTC", "BTCUSDT"},
+		time.UnixMilli(1101), time.UnixMilli(2000))
+	require.NoError(t, err)
+	require.Len(t, trades, 900)
+	for i, tr := range trades {
+		require.Equal(t, int64(101+i), tr.ID)
+	}
+
+	// trades after the known ones are requested by ID
+	trades, err = tradehistory.FetchFrom(client, map[string]int64{"BTCUSDT": 1190, "ETHBTC": 1198}, time.Time{})
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(trades))
+	for _, tr := range trades {
+		ids = append(ids, tr.ID)
+	}
+	require.Equal(t, []int64{1190, 1192, 1193, 1195, 1196, 1198, 1199, 1200}, ids)
+
+	_, err = tradehistory.Fetch(client, []string{""}, time.UnixMilli(1001), time.Time{})
+	require.ErrorIs(t, err, binance.ErrEmptySymbol)
+}
+
+func TestKlinesConverter(t *testing.T) {
+	mock := &historyClient{closes: map[string]string{"BNBUSDT": "300", "BNBBTC": "0.01"}}
+	c := tradehistory.NewKlinesConverter(binance.NewCustomClient(mock), info)
+
+	price, err := c.Price("BNB", "USDT", 120001)
+	require.NoError(t, err)
+	requireDec(t, "300", price)
+	price, err = c.Price("BNB", "USDT", 179999)
+	require.NoError(t, err)
+	requireDec(t, "300", price)
+	require.Equal(t, 1, mock.klines)
+
+	price, err = c.Price("BTC", "BNB", 120001)
+	require.NoError(t, err)
+	requireDec(t, "100", price)
+
+	price, err = c.Price("USDT", "USDT", 0)
+	require.NoError(t, err)
+	requireDec(t, "1", price)
+
+	_, err = c.Price("ETH", "USDT", 0)
+	require.ErrorIs(t, err, tradehistory.ErrNoPrice)
+	_, err = c.Price("BTC", "USDT", 0)
+	require.ErrorIs(t, err, tradehistory.ErrNoPrice)
+
+	book := tradehistory.NewBook(tradehistory.MethodAverageCost, info, c)
+	require.NoError(t, book.Add(withCommission(trade(1, "BTCUSDT", true, "1", "100"), "0.001", "BNB")))
+	p, _ := book.Position("BTCUSDT")
+	requireDec(t, "100.3", p.Cost)
+}
@@ -1,9 +1,9 @@
 package binance
 
 import (
-	"bytes"
+	"strconv"
 
-	"github.com/valyala/fasthttp"
+	"github.com/segmentio/encoding/json"
 	"github.com/xenking/decimal"
 )
 
@@ -23,13 +23,20 @@ const (
 type OrderStatus string
 
 const (
-	OrderStatusNew      OrderStatus = "NEW"
-	OrderStatusPartial  OrderStatus = "PARTIALLY_FILLED"
-	OrderStatusFilled   OrderStatus = "FILLED"
-	OrderStatusCanceled OrderStatus = "CANCELED"
-	OrderStatusPending  OrderStatus = "PENDING_CANCEL"
-	OrderStatusRejected OrderStatus = "REJECTED"
-	OrderStatusExpired  OrderStatus = "EXPIRED"
+	OrderStatusNew            OrderStatus = "NEW"
+	OrderStatusPendingNew     OrderStatus = "PENDING_NEW" // Pending order of OTO and OTOCO lists waiting for the working order fill
+	OrderStatusPartial        OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled         OrderStatus = "FILLED"
+	OrderStatusCanceled       OrderStatus = "CANCELED"
+	OrderStatusPending        OrderStatus = "PENDING_CANCEL"
+	OrderStatusRejected       OrderStatus = "REJECTED"
+	OrderStatusExpired        OrderStatus = "EXPIRED"
+	OrderStatusExpiredInMatch OrderStatus = "EXPIRED_IN_MATCH" // Order was expired by self-trade prevention
+
+	// Execution types of user data stream order updates, which aren't order statuses
+	OrderStatusReplaced        OrderStatus = "REPLACED" // Order was amended with keep priority
+	OrderStatusTrade           OrderStatus = "TRADE"
+	OrderStatusTradePrevention OrderStatus = "TRADE_PREVENTION" // Order was expired by self-trade prevention
 )
 
 type OrderFailure string
@@ -73,20 +80,21 @@ const (
 const MinStrategyType = 1000000
 
 type OrderReq struct {
-	Symbol           string        `url:"symbol"`
-	Side             OrderSide     `url:"side"`
-	Type             OrderType     `url:"type"`
-	TimeInForce      TimeInForce   `url:"timeInForce,omitempty"`
-	Quantity         string        `url:"quantity,omitempty"`
-	QuoteQuantity    string        `url:"quoteOrderQty,omitempty"`
-	Price            string        `url:"price,omitempty"`
-	NewClientOrderID string        `url:"newClientOrderId,omitempty"`
-	StrategyID       int           `url:"strategyId,omitempty"`
-	StrategyType     int           `url:"strategyType,omitempty"` // Should be more than 1000000
-	StopPrice        string        `url:"stopPrice,omitempty"`
-	TrailingDelta    int64         `url:"trailingDelta,omitempty"` // Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
-	IcebergQty       string        `url:"icebergQty,omitempty"`
-	OrderRespType    OrderRespType `url:"newOrderRespType,omitempty"`
+	Symbol                  string                  `url:"symbol"`
+	Side                    OrderSide               `url:"side"`
+	Type                    OrderType               `url:"type"`
+	TimeInForce             TimeInForce             `url:"timeInForce,omitempty"`
+	Quantity                string                  `url:"quantity,omitempty"`
+	QuoteQuantity           string                  `url:"quoteOrderQty,omitempty"`
+	Price                   string                  `url:"price,omitempty"`
+	NewClientOrderID        string                  `url:"newClientOrderId,omitempty"`
+	StrategyID              int                     `url:"strategyId,omitempty"`
+	StrategyType            int                     `url:"strategyType,omitempty"` // Should be more than 1000000
+	StopPrice               string                  `url:"stopPrice,omitempty"`
+	TrailingDelta           int64                   `url:"trailingDelta,omitempty"` // Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
+	IcebergQty              string                  `url:"icebergQty,omitempty"`
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
 }
 
 type OrderRespAck struct {
@@ -98,47 +106,54 @@ type OrderRespAck struct {
 }
 
 type OrderRespResult struct {
-	Symbol              string      `json:"symbol"`
-	OrderID             uint64      `json:"orderId"`
-	OrderListID         int         `json:"orderListId"`
-	ClientOrderID       string      `json:"clientOrderId"`
-	TransactTime        uint64      `json:"transactTime"`
-	Price               string      `json:"price"`
-	OrigQty             string      `json:"origQty"`
-	ExecutedQty         string      `json:"executedQty"`
-	CummulativeQuoteQty string      `json:"cummulativeQuoteQty"`
-	Status              OrderStatus `json:"status"`
-	TimeInForce         string      `json:"timeInForce"`
-	Type                OrderType   `json:"type"`
-	Side                OrderSide   `json:"side"`
-	StrategyID          int         `json:"strategyId,omitempty"`
-	StrategyType        int         `json:"strategyType,omitempty"`
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 uint64                  `json:"orderId"`
+	OrderListID             int                     `json:"orderListId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	TransactTime            uint64                  `json:"transactTime"`
+	Price                   decimal.Decimal         `json:"price"`
+	OrigQty                 decimal.Decimal         `json:"origQty"`
+	ExecutedQty             decimal.Decimal         `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal         `json:"cummulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             string                  `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	StrategyID              int                     `json:"strategyId,omitempty"`
+	StrategyType            int                     `json:"strategyType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode,omitempty"`
+	PreventedMatchID        int64                   `json:"preventedMatchId,omitempty"`
+	PreventedQuantity       decimal.Decimal         `json:"preventedQuantity"`
 }
 
 type OrderRespFull struct {
-	Symbol              string              `json:"symbol"`
-	OrderID             uint64              `json:"orderId"`
-	OrderListID         int64               `json:"orderListId"`
-	ClientOrderID       string              `json:"clientOrderId"`
-	TransactTime        uint64              `json:"transactTime"`
-	Price               string              `json:"price"`
-	OrigQty             string              `json:"origQty"`
-	ExecutedQty         string              `json:"executedQty"`
-	CummulativeQuoteQty string              `json:"cummulativeQuoteQty"`
-	Status              OrderStatus         `json:"status"`
-	TimeInForce         string              `json:"timeInForce"`
-	Type                OrderType           `json:"type"`
-	Side                OrderSide           `json:"side"`
-	StrategyID          int                 `json:"strategyId,omitempty"`
-	StrategyType        int                 `json:"strategyType,omitempty"`
-	Fills               []OrderRespFullFill `json:"fills"`
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 uint64                  `json:"orderId"`
+	OrderListID             int64                   `json:"orderListId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	TransactTime            uint64                  `json:"transactTime"`
+	Price                   decimal.Decimal         `json:"price"`
+	OrigQty                 decimal.Decimal         `json:"origQty"`
+	ExecutedQty             decimal.Decimal         `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal         `json:"cummulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             string                  `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	StrategyID              int                     `json:"strategyId,omitempty"`
+	StrategyType            int                     `json:"strategyType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode,omitempty"`
+	PreventedMatchID        int64                   `json:"preventedMatchId,omitempty"`
+	PreventedQuantity       decimal.Decimal         `json:"preventedQuantity"`
+	Fills                   []OrderRespFullFill     `json:"fills"`
 }
 
 type OrderRespFullFill struct {
-	Price           string `json:"price"`
-	Qty             string `json:"qty"`
-	Commission      string `json:"commission"`
-	CommissionAsset string `json:"commissionAsset"`
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TradeID         int64           `json:"tradeId"`
 }
 
 type ServerTime struct {
@@ -183,41 +198,37 @@ type DepthElem struct {
 	Price    decimal.Decimal `json:"price"`
 }
 
-// UnmarshalJSON unmarshal the given depth raw data and converts to depth struct
+// UnmarshalJSON unmarshal the given depth raw data and converts to depth struct.
+// It expects ["price","quantity"], whitespace and extra elements are ignored
 func (b *DepthElem) UnmarshalJSON(data []byte) error {
 	if b == nil {
 		return ErrNilUnmarshal
 	}
-
-	if len(data) <= 4 {
+	if isNull(data) {
 		return nil
 	}
-	qty, price := 3, 0
-	next := false
-	for qty < len(data)-1 {
-		if data[qty] == '"' {
-			if next {
-				break
-			}
-			next = true
-			price = qty
-			qty += 3
-
-			continue
-		}
-		qty++
-	}
-	if price < 3 || qty < 4 || !next {
-		return ErrInvalidJSON
-	}
-	var err error
-	b.Price, err = decimal.NewFromString(b2s(data[2:price]))
-	if err != nil {
+	p := newArrayParser("depth", data)
+	price := p.decimal()
+	qty := p.decimal()
+	if err := p.finish(); err != nil {
 		return err
 	}
-	b.Quantity, err = decimal.NewFromString(b2s(data[price+3 : qty]))
+	b.Price, b.Quantity = price, qty
 
-	return err
+	return nil
+}
+
+// MarshalJSON encodes depth element in Binance format ["price","quantity"].
+// Remark: the method is promoted to structs embedding DepthElem, they must define their own MarshalJSON
+func (b DepthElem) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 48)
+	buf = append(buf, '[', '"')
+	buf = AppendDecimal(buf, b.Price)
+	buf = append(buf, '"', ',', '"')
+	buf = AppendDecimal(buf, b.Quantity)
+	buf = append(buf, '"', ']')
+
+	return buf, nil
 }
 
 type Depth struct {
@@ -239,19 +250,26 @@ type TradeReq struct {
 
 // HistoricalTradeReq are used to specify symbol to get older trades
 type HistoricalTradeReq struct {
-	Symbol string `url:"symbol"` // Symbol is the symbol to fetch data for
-	Limit  int    `url:"limit"`  // Limit is the maximal number of elements to receive. Default 500; Max 1000
-	FromID int    `url:"fromId"` // FromID is trade ID to fetch from. Default gets most recent trades
+	Symbol string `url:"symbol"`           // Symbol is the symbol to fetch data for
+	Limit  int    `url:"limit"`            // Limit is the maximal number of elements to receive. Default 500; Max 1000
+	FromID *int64 `url:"fromId,omitempty"` // FromID is trade ID to fetch from. Nil gets most recent trades
+}
+
+// WithFromID sets the trade ID to fetch from, zero is a valid ID
+func (r *HistoricalTradeReq) WithFromID(id int64) *HistoricalTradeReq {
+	r.FromID = &id
+
+	return r
 }
 
 type Trade struct {
-	ID           int64  `json:"id"`
-	Price        string `json:"price"`
-	Qty          string `json:"qty"`
-	QuoteQty     string `json:"quoteQty"`
-	Time         int64  `json:"time"`
-	IsBuyerMaker bool   `json:"isBuyerMaker"`
-	IsBestMatch  bool   `json:"isBestMatch"`
+	ID           int64           `json:"id"`
+	Price        decimal.Decimal `json:"price"`
+	Qty          decimal.Decimal `json:"qty"`
+	QuoteQty     decimal.Decimal `json:"quoteQty"`
+	Time         int64           `json:"time"`
+	IsBuyerMaker bool            `json:"isBuyerMaker"`
+	IsBestMatch  bool            `json:"isBestMatch"`
 }
 
 const (
@@ -281,81 +299,74 @@ type Klines struct {
 	TakerBuyQuoteAssetVolume decimal.Decimal
 }
 
-var (
-	klinesQuote = []byte(`"`)
-	klinesDelim = []byte(`,`)
-)
-
-// UnmarshalJSON unmarshal the given depth raw data and converts to depth struct
+// UnmarshalJSON unmarshal the given klines raw data and converts to klines struct.
+// Whitespace, numbers in quotes and extra elements like the trailing "ignore" field are tolerated
 func (b *Klines) UnmarshalJSON(data []byte) error {
 	if b == nil {
 		return ErrNilUnmarshal
 	}
-	if len(data) == 0 {
+	if isNull(data) {
 		return nil
 	}
-	s := bytes.ReplaceAll(data, klinesQuote, nil)
-	tokens := bytes.Split(s, klinesDelim)
-	if len(tokens) < 11 {
-		return ErrInvalidJSON
-	}
-	u, err := fasthttp.ParseUint(tokens[0][1:])
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.OpenTime = uint64(u)
-	b.OpenPrice, err = decimal.NewFromString(b2s(tokens[1]))
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.High, err = decimal.NewFromString(b2s(tokens[2]))
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.Low, err = decimal.NewFromString(b2s(tokens[3]))
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.ClosePrice, err = decimal.NewFromString(b2s(tokens[4]))
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.Volume, err = decimal.NewFromString(b2s(tokens[5]))
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	u, err = fasthttp.ParseUint(tokens[6])
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.CloseTime = uint64(u)
-	b.QuoteAssetVolume, err = decimal.NewFromString(b2s(tokens[7]))
-	if err != nil {
-		return ErrInvalidJSON
+	p := newArrayParser("klines", data)
+	k := Klines{
+		OpenTime:                 p.uint(),
+		OpenPrice:                p.decimal(),
+		High:                     p.decimal(),
+		Low:                      p.decimal(),
+		ClosePrice:               p.decimal(),
+		Volume:                   p.decimal(),
+		CloseTime:                p.uint(),
+		QuoteAssetVolume:         p.decimal(),
+		Trades:                   int(p.uint()),
+		TakerBuyBaseAssetVolume:  p.decimal(),
+		TakerBuyQuoteAssetVolume: p.decimal(),
 	}
-	b.Trades, err = fasthttp.ParseUint(tokens[8])
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.TakerBuyBaseAssetVolume, err = decimal.NewFromString(b2s(tokens[9]))
-	if err != nil {
-		return ErrInvalidJSON
-	}
-	b.TakerBuyQuoteAssetVolume, err = decimal.NewFromString(b2s(tokens[10]))
-	if err != nil {
-		return ErrInvalidJSON
+	if err := p.finish(); err != nil {
+		return err
 	}
+	*b = k
 
 	return nil
 }
 
+// MarshalJSON encodes klines in Binance array format, the same as accepted by UnmarshalJSON.
+// Remark: the method is promoted to structs embedding Klines, they must define their own MarshalJSON, see ResampledKlines
+func (b Klines) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 192)
+	buf = append(buf, '[')
+	buf = strconv.AppendUint(buf, b.OpenTime, 10)
+	buf = appendQuotedDecimal(buf, b.OpenPrice)
+	buf = appendQuotedDecimal(buf, b.High)
+	buf = appendQuotedDecimal(buf, b.Low)
+	buf = appendQuotedDecimal(buf, b.ClosePrice)
+	buf = appendQuotedDecimal(buf, b.Volume)
+	buf = append(buf, ',')
+	buf = strconv.AppendUint(buf, b.CloseTime, 10)
+	buf = appendQuotedDecimal(buf, b.QuoteAssetVolume)
+	buf = append(buf, ',')
+	buf = strconv.AppendInt(buf, int64(b.Trades), 10)
+	buf = appendQuotedDecimal(buf, b.TakerBuyBaseAssetVolume)
+	buf = appendQuotedDecimal(buf, b.TakerBuyQuoteAssetVolume)
+	buf = append(buf, `,"0"]`...)
+
+	return buf, nil
+}
+
+func appendQuotedDecimal(buf []byte, d decimal.Decimal) []byte {
+	buf = append(buf, ',', '"')
+	buf = AppendDecimal(buf, d)
+
+	return append(buf, '"')
+}
+
 type AvgPriceReq struct {
 	Symbol string `url:"symbol"`
 }
 
 type AvgPrice struct {
-	Mins  int    `json:"mins"`
-	Price string `json:"price"`
+	Mins  int             `json:"mins"`
+	Price decimal.Decimal `json:"price"`
 }
 
 type BookTickerReq struct {
@@ -363,11 +374,11 @@ type BookTickerReq struct {
 }
 
 type BookTicker struct {
-	Symbol   string `json:"symbol"`
-	BidPrice string `json:"bidPrice"`
-	BidQty   string `json:"bidQty"`
-	AskPrice string `json:"askPrice"`
-	AskQty   string `json:"askQty"`
+	Symbol   string          `json:"symbol"`
+	BidPrice decimal.Decimal `json:"bidPrice"`
+	BidQty   decimal.Decimal `json:"bidQty"`
+	AskPrice decimal.Decimal `json:"askPrice"`
+	AskQty   decimal.Decimal `json:"askQty"`
 }
 
 // TickerReq represents the request for a specified ticker
@@ -377,25 +388,25 @@ type TickerReq struct {
 
 // TickerStats is the stats for a specific symbol
 type TickerStats struct {
-	Symbol             string `json:"symbol"`
-	PriceChange        string `json:"priceChange"`
-	PriceChangePercent string `json:"priceChangePercent"`
-	WeightedAvgPrice   string `json:"weightedAvgPrice"`
-	PrevClosePrice     string `json:"prevClosePrice"`
-	LastPrice          string `json:"lastPrice"`
-	LastQty            string `json:"lastQty"`
-	BidPrice           string `json:"bidPrice"`
-	AskPrice           string `json:"askPrice"`
-	OpenPrice          string `json:"openPrice"`
-	HighPrice          string `json:"highPrice"` // HighPrice is 24hr high price
-	LowPrice           string `json:"lowPrice"`  // LowPrice is 24hr low price
-	Volume             string `json:"volume"`
-	QuoteVolume        string `json:"quoteVolume"`
-	OpenTime           uint64 `json:"openTime"`
-	CloseTime          uint64 `json:"closeTime"`
-	FirstID            int    `json:"firstId"`
-	LastID             int    `json:"lastId"`
-	Count              int    `json:"count"`
+	Symbol             string          `json:"symbol"`
+	PriceChange        decimal.Decimal `json:"priceChange"`
+	PriceChangePercent decimal.Decimal `json:"priceChangePercent"`
+	WeightedAvgPrice   decimal.Decimal `json:"weightedAvgPrice"`
+	PrevClosePrice     decimal.Decimal `json:"prevClosePrice"`
+	LastPrice          decimal.Decimal `json:"lastPrice"`
+	LastQty            decimal.Decimal `json:"lastQty"`
+	BidPrice           decimal.Decimal `json:"bidPrice"`
+	AskPrice           decimal.Decimal `json:"askPrice"`
+	OpenPrice          decimal.Decimal `json:"openPrice"`
+	HighPrice          decimal.Decimal `json:"highPrice"` // HighPrice is 24hr high price
+	LowPrice           decimal.Decimal `json:"lowPrice"`  // LowPrice is 24hr low price
+	Volume             decimal.Decimal `json:"volume"`
+	QuoteVolume        decimal.Decimal `json:"quoteVolume"`
+	OpenTime           uint64          `json:"openTime"`
+	CloseTime          uint64          `json:"closeTime"`
+	FirstID            int             `json:"firstId"`
+	LastID             int             `json:"lastId"`
+	Count              int             `json:"count"`
 }
 
 type TickerPriceReq struct {
@@ -404,7 +415,7 @@ type TickerPriceReq struct {
 
 type SymbolPrice struct {
 	Symbol string
-	Price  string
+	Price  decimal.Decimal
 }
 
 // QueryOrderReq represents the request for querying an order
@@ -416,51 +427,58 @@ type QueryOrderReq struct {
 }
 
 type QueryOrder struct {
-	Symbol              string      `json:"symbol"`
-	OrderID             uint64      `json:"orderId"`
-	OrderListID         int64       `json:"orderListId"`
-	ClientOrderID       string      `json:"clientOrderId"`
-	Price               string      `json:"price"`
-	OrigQty             string      `json:"origQty"`
-	ExecutedQty         string      `json:"executedQty"`
-	CummulativeQuoteQty string      `json:"cummulativeQuoteQty"`
-	Status              OrderStatus `json:"status"`
-	TimeInForce         TimeInForce `json:"timeInForce"`
-	Type                OrderType   `json:"type"`
-	Side                OrderSide   `json:"side"`
-	StopPrice           string      `json:"stopPrice"`
-	IcebergQty          string      `json:"IcebergQty"`
-	Time                uint64      `json:"time"`
-	UpdateTime          uint64      `json:"updateTime"`
-	OrigQuoteOrderQty   string      `json:"origQuoteOrderQty"`
-	StrategyID          int         `json:"strategyId,omitempty"`
-	StrategyType        int         `json:"strategyType,omitempty"`
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 uint64                  `json:"orderId"`
+	OrderListID             int64                   `json:"orderListId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   decimal.Decimal         `json:"price"`
+	OrigQty                 decimal.Decimal         `json:"origQty"`
+	ExecutedQty             decimal.Decimal         `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal         `json:"cummulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	StopPrice               decimal.Decimal         `json:"stopPrice"`
+	IcebergQty              decimal.Decimal         `json:"IcebergQty"`
+	Time                    uint64                  `json:"time"`
+	UpdateTime              uint64                  `json:"updateTime"`
+	OrigQuoteOrderQty       decimal.Decimal         `json:"origQuoteOrderQty"`
+	StrategyID              int                     `json:"strategyId,omitempty"`
+	StrategyType            int                     `json:"strategyType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode,omitempty"`
+	PreventedMatchID        int64                   `json:"preventedMatchId,omitempty"`
+	PreventedQuantity       decimal.Decimal         `json:"preventedQuantity"`
+	WorkingFloor            WorkingFloor            `json:"workingFloor,omitempty"`
+	UsedSor                 bool                    `json:"usedSor,omitempty"`
 }
 
 // Remark: Either OrderID or OrigOrderID must be set
 type CancelOrderReq struct {
-	Symbol            string `url:"symbol"`
-	OrderID           uint64 `url:"orderId,omitempty"`
-	OrigClientOrderID string `url:"origClientOrderId,omitempty"`
-	NewClientOrderID  string `url:"newClientOrderId,omitempty"`
+	Symbol             string             `url:"symbol"`
+	OrderID            uint64             `url:"orderId,omitempty"`
+	OrigClientOrderID  string             `url:"origClientOrderId,omitempty"`
+	NewClientOrderID   string             `url:"newClientOrderId,omitempty"`
+	CancelRestrictions CancelRestrictions `url:"cancelRestrictions,omitempty"`
 }
 
 type CancelOrder struct {
-	Symbol              string      `json:"symbol"`
-	OrigClientOrderID   string      `json:"origClientOrderId"`
-	OrderID             uint64      `json:"orderId"`
-	OrderListID         int64       `json:"orderListId"`
-	ClientOrderID       string      `json:"clientOrderId"`
-	Price               string      `json:"price"`
-	OrigQty             string      `json:"origQty"`
-	ExecutedQty         string      `json:"executedQty"`
-	CummulativeQuoteQty string      `json:"cummulativeQuoteQty"`
-	Status              OrderStatus `json:"status"`
-	TimeInForce         TimeInForce `json:"timeInForce"`
-	Type                OrderType   `json:"type"`
-	Side                OrderSide   `json:"side"`
-	StrategyID          int         `json:"strategyId,omitempty"`
-	StrategyType        int         `json:"strategyType,omitempty"`
+	Symbol                  string                  `json:"symbol"`
+	OrigClientOrderID       string                  `json:"origClientOrderId"`
+	OrderID                 uint64                  `json:"orderId"`
+	OrderListID             int64                   `json:"orderListId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   decimal.Decimal         `json:"price"`
+	OrigQty                 decimal.Decimal         `json:"origQty"`
+	ExecutedQty             decimal.Decimal         `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal         `json:"cummulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	StrategyID              int                     `json:"strategyId,omitempty"`
+	StrategyType            int                     `json:"strategyType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode,omitempty"`
 }
 
 type CancelReplaceResult string
@@ -478,20 +496,103 @@ const (
 	CancelReplaceModeAllowFailure  CancelReplaceMode = "ALLOW_FAILURE"
 )
 
+// CancelRestrictions allows canceling the order only when it has the given status
+type CancelRestrictions string
+
+const (
+	CancelRestrictionsOnlyNew             CancelRestrictions = "ONLY_NEW"
+	CancelRestrictionsOnlyPartiallyFilled CancelRestrictions = "ONLY_PARTIALLY_FILLED"
+)
+
+// OrderRateLimitExceededMode defines whether the cancel is still done when the unfilled order count is exceeded
+type OrderRateLimitExceededMode string
+
+const (
+	OrderRateLimitExceededModeDoNothing  OrderRateLimitExceededMode = "DO_NOTHING"
+	OrderRateLimitExceededModeCancelOnly OrderRateLimitExceededMode = "CANCEL_ONLY"
+)
+
 // Note: Either CancelOrderID or CancelOrigClientOrderID must be set
 type CancelReplaceOrderReq struct {
 	OrderReq
-	CancelReplaceMode       CancelReplaceMode `url:"cancelReplaceMode"`
-	CancelOrderID           uint64            `url:"cancelOrderId,omitempty"`
-	CancelOrigClientOrderID string            `url:"cancelOrigClientOrderId,omitempty"`
-	CancelNewClientOrderID  string            `url:"cancelNewClientOrderId,omitempty"`
+	CancelReplaceMode          CancelReplaceMode          `url:"cancelReplaceMode"`
+	CancelOrderID              uint64                     `url:"cancelOrderId,omitempty"`
+	CancelOrigClientOrderID    string                     `url:"cancelOrigClientOrderId,omitempty"`
+	CancelNewClientOrderID     string                     `url:"cancelNewClientOrderId,omitempty"`
+	CancelRestrictions         CancelRestrictions         `url:"cancelRestrictions,omitempty"`
+	OrderRateLimitExceededMode OrderRateLimitExceededMode `url:"orderRateLimitExceededMode,omitempty"`
 }
 
+// CancelReplaceOrder is the result of the cancel-replace.
+// Remark: CancelError and NewOrderError are set instead of the responses when the operation failed
 type CancelReplaceOrder struct {
 	CancelResponse   CancelOrder         `json:"cancelResponse"`
 	NewOrderResponse *OrderRespFull      `json:"newOrderResponse,omitempty"`
 	CancelStatus     CancelReplaceResult `json:"cancelResult"`
 	NewOrderResult   CancelReplaceResult `json:"newOrderResult"`
+	CancelError      *APIError           `json:"-"`
+	NewOrderError    *APIError           `json:"-"`
+}
+
+// CancelSucceeded reports whether the original order was canceled
+func (o *CancelReplaceOrder) CancelSucceeded() bool {
+	return o.CancelStatus == CancelReplaceResultSuccess
+}
+
+// NewOrderSucceeded reports whether the new order was placed
+func (o *CancelReplaceOrder) NewOrderSucceeded() bool {
+	return o.NewOrderResult == CancelReplaceResultSuccess
+}
+
+// UnmarshalJSON decodes the cancel-replace result, failed operations responses are decoded as errors
+func (o *CancelReplaceOrder) UnmarshalJSON(data []byte) error {
+	if o == nil {
+		return ErrNilUnmarshal
+	}
+	var raw struct {
+		CancelResponse   json.RawMessage     `json:"cancelResponse"`
+		NewOrderResponse json.RawMessage     `json:"newOrderResponse"`
+		CancelStatus     CancelReplaceResult `json:"cancelResult"`
+		NewOrderResult   CancelReplaceResult `json:"newOrderResult"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*o = CancelReplaceOrder{
+		CancelStatus:   raw.CancelStatus,
+		NewOrderResult: raw.NewOrderResult,
+	}
+	var err error
+	o.CancelError, err = unmarshalCancelReplacePart(raw.CancelResponse, &o.CancelResponse)
+	if err != nil {
+		return err
+	}
+	newOrder := &OrderRespFull{}
+	o.NewOrderError, err = unmarshalCancelReplacePart(raw.NewOrderResponse, newOrder)
+	if err != nil {
+		return err
+	}
+	if o.NewOrderError == nil && !isNull(raw.NewOrderResponse) {
+		o.NewOrderResponse = newOrder
+	}
+
+	return nil
+}
+
+// unmarshalCancelReplacePart decodes either the operation response into v or the operation error
+func unmarshalCancelReplacePart(data []byte, v interface{}) (*APIError, error) {
+	if isNull(data) {
+		return nil, nil
+	}
+	apiErr := &APIError{}
+	if err := json.Unmarshal(data, apiErr); err != nil {
+		return nil, err
+	}
+	if apiErr.Code != 0 {
+		return apiErr, nil
+	}
+
+	return nil, json.Unmarshal(data, v)
 }
 
 type OpenOrdersReq struct {
@@ -518,9 +619,9 @@ type AllOrdersReq struct {
 }
 
 type Balance struct {
-	Asset  string `json:"asset"`
-	Free   string `json:"free"`
-	Locked string `json:"locked"`
+	Asset  string          `json:"asset"`
+	Free   decimal.Decimal `json:"free"`
+	Locked decimal.Decimal `json:"locked"`
 }
 
 type AccountType string
@@ -536,13 +637,14 @@ const (
 )
 
 type AccountInfo struct {
-	MakerCommission  int  `json:"makerCommission"`
-	TakerCommission  int  `json:"takerCommission"`
-	BuyerCommission  int  `json:"buyerCommission"`
-	SellerCommission int  `json:"sellerCommission"`
-	CanTrade         bool `json:"canTrade"`
-	CanWithdraw      bool `json:"canWithdraw"`
-	CanDeposit       bool `json:"canDeposit"`
+	MakerCommission  int             `json:"makerCommission"` // MakerCommission is in basis points, use CommissionRates instead
+	TakerCommission  int             `json:"takerCommission"`
+	BuyerCommission  int             `json:"buyerCommission"`
+	SellerCommission int             `json:"sellerCommission"`
+	CommissionRates  CommissionRates `json:"commissionRates"`
+	CanTrade         bool            `json:"canTrade"`
+	CanWithdraw      bool            `json:"canWithdraw"`
+	CanDeposit       bool            `json:"canDeposit"`
 	AccountType      AccountType
 	Balances         []*Balance    `json:"balances"`
 	Permissions      []AccountType `json:"permissions"`
@@ -554,25 +656,32 @@ type AccountTradesReq struct {
 	Symbol    string `url:"symbol"`
 	OrderID   string `url:"orderId,omitempty"` // OrderID can only be used in combination with symbol
 	Limit     int    `url:"limit,omitempty"`   // Limit is the maximal number of elements to receive. Default 500; Max 1000
-	FromID    int    `url:"fromId,omitempty"`  // FromID is trade ID to fetch from. Default gets most recent trades
+	FromID    *int64 `url:"fromId,omitempty"`  // FromID is trade ID to fetch from. Default gets most recent trades
 	StartTime uint64 `url:"startTime,omitempty"`
 	EndTime   uint64 `url:"endTime,omitempty"`
 }
 
+// WithFromID sets the trade ID to fetch from, zero is a valid ID
+func (r *AccountTradesReq) WithFromID(id int64) *AccountTradesReq {
+	r.FromID = &id
+
+	return r
+}
+
 type AccountTrades struct {
-	ID              int64  `json:"id"`
-	OrderID         uint64 `json:"orderId"`
-	OrderListID     int64  `json:"orderListId"`
-	Symbol          string `json:"symbol"`
-	QuoteQty        string `json:"quoteQty"`
-	Price           string `json:"price"`
-	Qty             string `json:"qty"`
-	Commission      string `json:"commission"`
-	CommissionAsset string `json:"commissionAsset"`
-	Time            uint64 `json:"time"`
-	Buyer           bool   `json:"isBuyer"`
-	Maker           bool   `json:"isMaker"`
-	BestMatch       bool   `json:"isBestMatch"`
+	ID              int64           `json:"id"`
+	OrderID         uint64          `json:"orderId"`
+	OrderListID     int64           `json:"orderListId"`
+	Symbol          string          `json:"symbol"`
+	QuoteQty        decimal.Decimal `json:"quoteQty"`
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	Time            uint64          `json:"time"`
+	Buyer           bool            `json:"isBuyer"`
+	Maker           bool            `json:"isMaker"`
+	BestMatch       bool            `json:"isBestMatch"`
 }
 
 type DatastreamReq struct {
@@ -581,21 +690,28 @@ type DatastreamReq struct {
 
 type AggregatedTradeReq struct {
 	Symbol    string `url:"symbol"`              // Symbol is the symbol to fetch data for
-	FromID    int    `url:"fromId"`              // FromID to get aggregate trades from INCLUSIVE.
+	FromID    *int64 `url:"fromId,omitempty"`    // FromID to get aggregate trades from INCLUSIVE. Nil isn't sent
 	Limit     int    `url:"limit"`               // Limit is the maximal number of elements to receive. Default 500; Max 1000
 	StartTime uint64 `url:"startTime,omitempty"` // StartTime timestamp in ms to get aggregate trades from INCLUSIVE.
 	EndTime   uint64 `url:"endTime,omitempty"`   // EndTime timestamp in ms to get aggregate trades until INCLUSIVE.
 }
 
+// WithFromID sets the aggregate trade ID to fetch from, zero is a valid ID
+func (r *AggregatedTradeReq) WithFromID(id int64) *AggregatedTradeReq {
+	r.FromID = &id
+
+	return r
+}
+
 type AggregatedTrade struct {
-	TradeID      int64  `json:"a"` // TradeID is the aggregate trade ID
-	Price        string `json:"p"` // Price is the trade price
-	Quantity     string `json:"q"` // Quantity is the trade quantity
-	FirstTradeID int    `json:"f"`
-	LastTradeID  int    `json:"l"`
-	Time         uint64 `json:"T"`
-	Maker        bool   `json:"m"` // Maker indicates if the buyer is the maker
-	BestMatch    bool   `json:"M"` // BestMatch indicates if the trade was at the best price match
+	TradeID      int64           `json:"a"` // TradeID is the aggregate trade ID
+	Price        decimal.Decimal `json:"p"` // Price is the trade price
+	Quantity     decimal.Decimal `json:"q"` // Quantity is the trade quantity
+	FirstTradeID int             `json:"f"`
+	LastTradeID  int             `json:"l"`
+	Time         uint64          `json:"T"`
+	Maker        bool            `json:"m"` // Maker indicates if the buyer is the maker
+	BestMatch    bool            `json:"M"` // BestMatch indicates if the trade was at the best price match
 }
 
 type ExchangeInfoReq struct {
@@ -608,6 +724,7 @@ type ExchangeInfo struct {
 	RateLimits      []RateLimit      `json:"rateLimits"`
 	ExchangeFilters []ExchangeFilter `json:"exchangeFilters"`
 	Symbols         []SymbolInfo     `json:"symbols"`
+	SORs            []SOR            `json:"sors,omitempty"`
 }
 
 type RateLimitType string
@@ -649,8 +766,13 @@ type RateLimit struct {
 type ExchangeFilterType string
 
 const (
-	ExchangeFilterTypeMaxNumOrders  ExchangeFilterType = "EXCHANGE_MAX_NUM_ORDERS"
-	ExchangeFilterTypeMaxAlgoOrders ExchangeFilterType = "EXCHANGE_MAX_ALGO_ORDERS"
+	ExchangeFilterTypeMaxNumOrders        ExchangeFilterType = "EXCHANGE_MAX_NUM_ORDERS"
+	ExchangeFilterTypeMaxNumAlgoOrders    ExchangeFilterType = "EXCHANGE_MAX_NUM_ALGO_ORDERS"
+	ExchangeFilterTypeMaxNumIcebergOrders ExchangeFilterType = "EXCHANGE_MAX_NUM_ICEBERG_ORDERS"
+	ExchangeFilterTypeMaxNumOrderLists    ExchangeFilterType = "EXCHANGE_MAX_NUM_ORDER_LISTS"
+
+	// Deprecated: use ExchangeFilterTypeMaxNumAlgoOrders
+	ExchangeFilterTypeMaxAlgoOrders = ExchangeFilterTypeMaxNumAlgoOrders
 )
 
 type ExchangeFilter struct {
@@ -659,30 +781,40 @@ type ExchangeFilter struct {
 	// EXCHANGE_MAX_NUM_ORDERS parameters
 	MaxNumOrders int `json:"maxNumOrders"`
 
-	// EXCHANGE_MAX_ALGO_ORDERS parameters
+	// EXCHANGE_MAX_NUM_ALGO_ORDERS parameters
 	MaxNumAlgoOrders int `json:"maxNumAlgoOrders"`
+
+	// EXCHANGE_MAX_NUM_ICEBERG_ORDERS parameters
+	MaxNumIcebergOrders int `json:"maxNumIcebergOrders"`
+
+	// EXCHANGE_MAX_NUM_ORDER_LISTS parameters
+	MaxNumOrderLists int `json:"maxNumOrderLists"`
 }
 
 type SymbolInfo struct {
-	Symbol                     string             `json:"symbol"`
-	Status                     SymbolStatus       `json:"status"`
-	BaseAsset                  string             `json:"baseAsset"`
-	BaseAssetPrecision         int                `json:"baseAssetPrecision"`
-	QuoteAsset                 string             `json:"quoteAsset"`
-	QuotePrecision             int                `json:"quotePrecision"`
-	QuoteAssetPrecision        int                `json:"quoteAssetPrecision"`
-	BaseCommissionPrecision    int                `json:"baseCommissionPrecision"`
-	QuoteCommissionPrecision   int                `json:"quoteCommissionPrecision"`
-	OrderTypes                 []OrderType        `json:"orderTypes"`
-	IcebergAllowed             bool               `json:"icebergAllowed"`
-	OCOAllowed                 bool               `json:"ocoAllowed"`
-	QuoteOrderQtyMarketAllowed bool               `json:"quoteOrderQtyMarketAllowed"`
-	AllowTrailingStop          bool               `json:"allowTrailingStop"`
-	IsSpotTradingAllowed       bool               `json:"isSpotTradingAllowed"`
-	IsMarginTradingAllowed     bool               `json:"isMarginTradingAllowed"`
-	CancelReplaceAllowed       bool               `json:"cancelReplaceAllowed"`
-	Filters                    []SymbolInfoFilter `json:"filters"`
-	Permissions                []AccountType      `json:"permissions"`
+	Symbol                          string                    `json:"symbol"`
+	Status                          SymbolStatus              `json:"status"`
+	BaseAsset                       string                    `json:"baseAsset"`
+	BaseAssetPrecision              int                       `json:"baseAssetPrecision"`
+	QuoteAsset                      string                    `json:"quoteAsset"`
+	QuotePrecision                  int                       `json:"quotePrecision"`
+	QuoteAssetPrecision             int                       `json:"quoteAssetPrecision"`
+	BaseCommissionPrecision         int                       `json:"baseCommissionPrecision"`
+	QuoteCommissionPrecision        int                       `json:"quoteCommissionPrecision"`
+	OrderTypes                      []OrderType               `json:"orderTypes"`
+	IcebergAllowed                  bool                      `json:"icebergAllowed"`
+	OCOAllowed                      bool                      `json:"ocoAllowed"`
+	OTOAllowed                      bool                      `json:"otoAllowed"`
+	QuoteOrderQtyMarketAllowed      bool                      `json:"quoteOrderQtyMarketAllowed"`
+	AllowTrailingStop               bool                      `json:"allowTrailingStop"`
+	IsSpotTradingAllowed            bool                      `json:"isSpotTradingAllowed"`
+	IsMarginTradingAllowed          bool                      `json:"isMarginTradingAllowed"`
+	CancelReplaceAllowed            bool                      `json:"cancelReplaceAllowed"`
+	DefaultSelfTradePreventionMode  SelfTradePreventionMode   `json:"defaultSelfTradePreventionMode"`
+	AllowedSelfTradePreventionModes []SelfTradePreventionMode `json:"allowedSelfTradePreventionModes"`
+	Filters                         []SymbolInfoFilter        `json:"filters"`
+	Permissions                     []AccountType             `json:"permissions"`
+	PermissionSets                  [][]AccountType           `json:"permissionSets"` // PermissionSets replace empty Permissions, an account needs all permissions of any set
 }
 
 type SymbolStatus string
@@ -702,42 +834,63 @@ type FilterType string
 const (
 	FilterTypePrice               FilterType = "PRICE_FILTER"
 	FilterTypePercentPrice        FilterType = "PERCENT_PRICE"
+	FilterTypePercentPriceBySide  FilterType = "PERCENT_PRICE_BY_SIDE"
 	FilterTypeLotSize             FilterType = "LOT_SIZE"
 	FilterTypeMinNotional         FilterType = "MIN_NOTIONAL"
+	FilterTypeNotional            FilterType = "NOTIONAL"
 	FilterTypeIcebergParts        FilterType = "ICEBERG_PARTS"
 	FilterTypeMarketLotSize       FilterType = "MARKET_LOT_SIZE"
 	FilterTypeMaxNumOrders        FilterType = "MAX_NUM_ORDERS"
 	FilterTypeMaxNumAlgoOrders    FilterType = "MAX_NUM_ALGO_ORDERS"
 	FilterTypeMaxNumIcebergOrders FilterType = "MAX_NUM_ICEBERG_ORDERS"
+	FilterTypeMaxNumOrderLists    FilterType = "MAX_NUM_ORDER_LISTS"
+	FilterTypeMaxNumOrderAmends   FilterType = "MAX_NUM_ORDER_AMENDS"
 	FilterTypeMaxPosition         FilterType = "MAX_POSITION"
+	FilterTypeTrailingDelta       FilterType = "TRAILING_DELTA"
+	FilterTypeTPlusSell           FilterType = "T_PLUS_SELL"
 )
 
 type SymbolInfoFilter struct {
 	Type FilterType `json:"filterType"`
 
 	// PRICE_FILTER parameters
-	MinPrice string `json:"minPrice"`
-	MaxPrice string `json:"maxPrice"`
-	TickSize string `json:"tickSize"`
+	MinPrice decimal.Decimal `json:"minPrice"`
+	MaxPrice decimal.Decimal `json:"maxPrice"`
+	TickSize decimal.Decimal `json:"tickSize"`
 
 	// PERCENT_PRICE parameters
-	MultiplierUp   string `json:"multiplierUp"`
-	MultiplierDown string `json:"multiplierDown"`
-	AvgPriceMins   int    `json:"avgPriceMins"`
+	MultiplierUp   decimal.Decimal `json:"multiplierUp"`
+	MultiplierDown decimal.Decimal `json:"multiplierDown"`
+
+	// PERCENT_PRICE_BY_SIDE parameters
+	BidMultiplierUp   decimal.Decimal `json:"bidMultiplierUp"`
+	BidMultiplierDown decimal.Decimal `json:"bidMultiplierDown"`
+	AskMultiplierUp   decimal.Decimal `json:"askMultiplierUp"`
+	AskMultiplierDown decimal.Decimal `json:"askMultiplierDown"`
+
+	// PERCENT_PRICE, PERCENT_PRICE_BY_SIDE, MIN_NOTIONAL or NOTIONAL parameter
+	AvgPriceMins int `json:"avgPriceMins"`
 
 	// LOT_SIZE or MARKET_LOT_SIZE parameters
-	MinQty   string `json:"minQty"`
-	MaxQty   string `json:"maxQty"`
-	StepSize string `json:"stepSize"`
+	MinQty   decimal.Decimal `json:"minQty"`
+	MaxQty   decimal.Decimal `json:"maxQty"`
+	StepSize decimal.Decimal `json:"stepSize"`
+
+	// MIN_NOTIONAL or NOTIONAL parameter
+	MinNotional decimal.Decimal `json:"minNotional"`
 
 	// MIN_NOTIONAL parameter
-	MinNotional   string `json:"minNotional"`
-	ApplyToMarket bool   `json:"applyToMarket"`
+	ApplyToMarket bool `json:"applyToMarket"`
+
+	// NOTIONAL parameters
+	ApplyMinToMarket bool            `json:"applyMinToMarket"`
+	MaxNotional      decimal.Decimal `json:"maxNotional"`
+	ApplyMaxToMarket bool            `json:"applyMaxToMarket"`
 
 	// ICEBERG_PARTS parameter
 	IcebergLimit int `json:"limit"`
 
-	// TRAILING_DELTA parameter
+	// TRAILING_DELTA parameters
 	MinTrailingAboveDelta int `json:"minTrailingAboveDelta"`
 	MaxTrailingAboveDelta int `json:"maxTrailingAboveDelta"`
 	MinTrailingBelowDelta int `json:"minTrailingBelowDelta"`
@@ -752,6 +905,15 @@ type SymbolInfoFilter struct {
 	// MAX_NUM_ICEBERG_ORDERS parameter
 	MaxNumIcebergOrders int `json:"maxNumIcebergOrders"`
 
+	// MAX_NUM_ORDER_LISTS parameter
+	MaxNumOrderLists int `json:"maxNumOrderLists"`
+
+	// MAX_NUM_ORDER_AMENDS parameter
+	MaxNumOrderAmends int `json:"maxNumOrderAmends"`
+
 	// MAX_POSITION parameter
-	MaxPosition string `json:"maxPosition"`
+	MaxPosition decimal.Decimal `json:"maxPosition"`
+
+	// T_PLUS_SELL parameter
+	EndTime uint64 `json:"endTime"`
 }
@@ -0,0 +1,382 @@
+package binance
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+)
+
+// FilterViolation describes a single symbol filter that an order doesn't pass
+type FilterViolation struct {
+	Filter FilterType
+	Reason string
+}
+
+// Error return filter type and reason
+func (v FilterViolation) Error() string {
+	return string(v.Filter) + ": " + v.Reason
+}
+
+// OrderValidationError contains every symbol filter violated by an order
+type OrderValidationError struct {
+	Symbol     string
+	Violations []FilterViolation
+}
+
+// Error return all violations joined into a single message
+func (e *OrderValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("order for ")
+	b.WriteString(e.Symbol)
+	b.WriteString(" violates filters: ")
+	for i, v := range e.Violations {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(v.Error())
+	}
+
+	return b.String()
+}
+
+// Has reports whether the given filter was violated
+func (e *OrderValidationError) Has(t FilterType) bool {
+	for _, v := range e.Violations {
+		if v.Filter == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orderValues are the parsed decimal values of an OrderReq
+type orderValues struct {
+	price, stopPrice, qty, quoteQty, icebergQty decimal.Decimal
+}
+
+func parseOrderValues(req *OrderReq) (v orderValues, err error) {
+	parse := func(name, s string) decimal.Decimal {
+		if s == "" || err != nil {
+			return decimal.Zero
+		}
+		var d decimal.Decimal
+		d, err = decimal.NewFromString(s)
+		if err != nil {
+			err = errors.Wrapf(err, "parse %s", name)
+		}
+
+		return d
+	}
+	v.price = parse("price", req.Price)
+	v.stopPrice = parse("stopPrice", req.StopPrice)
+	v.qty = parse("quantity", req.Quantity)
+	v.quoteQty = parse("quoteOrderQty", req.QuoteQuantity)
+	v.icebergQty = parse("icebergQty", req.IcebergQty)
+
+	return v, err
+}
+
+// ValidateOrder checks the order against every symbol filter and returns *OrderValidationError listing all violations.
+// avgPrice is the current average price of the symbol (see Client.AvgPrice), it's used by PERCENT_PRICE,
+// PERCENT_PRICE_BY_SIDE and by notional filters for market orders. Those checks are skipped when avgPrice is zero.
+// Account dependent filters (MAX_NUM_ORDERS, MAX_POSITION etc.) are not checked.
+func (s *SymbolInfo) ValidateOrder(req *OrderReq, avgPrice decimal.Decimal) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	if req.Symbol != s.Symbol {
+		return ErrSymbolMismatch
+	}
+	v, err := parseOrderValues(req)
+	if err != nil {
+		return err
+	}
+
+	var violations []FilterViolation
+	report := func(t FilterType, reason string) {
+		violations = append(violations, FilterViolation{Filter: t, Reason: reason})
+	}
+
+	if f := s.PriceFilter(); f != nil {
+		if req.Price != "" {
+			if reason := f.check(v.price); reason != "" {
+				report(FilterTypePrice, "price "+reason)
+			}
+		}
+		if req.StopPrice != "" {
+			if reason := f.check(v.stopPrice); reason != "" {
+				report(FilterTypePrice, "stop price "+reason)
+			}
+		}
+	}
+
+	if req.Quantity != "" {
+		if f := s.LotSize(); f != nil {
+			if reason := f.check(v.qty); reason != "" {
+				report(FilterTypeLotSize, "quantity "+reason)
+			}
+		}
+		if f := s.MarketLotSize(); f != nil && req.Type == OrderTypeMarket {
+			if reason := f.check(v.qty); reason != "" {
+				report(FilterTypeMarketLotSize, "quantity "+reason)
+			}
+		}
+	}
+
+	s.validateNotional(req, v, avgPrice, report)
+
+	if !avgPrice.IsZero() && req.Price != "" {
+		if f := s.PercentPrice(); f != nil {
+			if reason := checkPercentPrice(v.price, avgPrice, f.MultiplierUp, f.MultiplierDown); reason != "" {
+				report(FilterTypePercentPrice, reason)
+			}
+		}
+		if f := s.PercentPriceBySide(); f != nil {
+			up, down := f.AskMultiplierUp, f.AskMultiplierDown
+			if req.Side == OrderSideBuy {
+				up, down = f.BidMultiplierUp, f.BidMultiplierDown
+			}
+			if reason := checkPercentPrice(v.price, avgPrice, up, down); reason != "" {
+				report(FilterTypePercentPriceBySide, reason)
+			}
+		}
+	}
+
+	if req.IcebergQty != "" {
+		if limit, ok := s.IcebergParts(); ok && v.icebergQty.IsPositive() {
+			parts := v.qty.Div(v.icebergQty).Ceil()
+			if parts.GreaterThan(decimal.NewFromInt(int64(limit))) {
+				report(FilterTypeIcebergParts, "order splits into "+parts.String()+" parts, limit is "+strconv.Itoa(limit))
+			}
+		}
+		if f := s.LotSize(); f != nil {
+			if reason := f.check(v.icebergQty); reason != "" {
+				report(FilterTypeLotSize, "iceberg quantity "+reason)
+			}
+		}
+	}
+
+	if req.TrailingDelta != 0 {
+		if f := s.TrailingDelta(); f != nil {
+			if reason := f.check(req); reason != "" {
+				report(FilterTypeTrailingDelta, reason)
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &OrderValidationError{Symbol: s.Symbol, Violations: violations}
+	}
+
+	return nil
+}
+
+func (s *SymbolInfo) validateNotional(req *OrderReq, v orderValues, avgPrice decimal.Decimal, report func(FilterType, string)) {
+	minNotional, notional := s.MinNotional(), s.Notional()
+	if minNotional == nil && notional == nil {
+		return
+	}
+
+	market := req.Type == OrderTypeMarket
+	var value decimal.Decimal
+	switch {
+	case market && req.QuoteQuantity != "":
+		value = v.quoteQty
+	case market:
+		if avgPrice.IsZero() {
+			return
+		}
+		value = v.qty.Mul(avgPrice)
+	case req.Price != "" && req.Quantity != "":
+		value = v.qty.Mul(v.price)
+	default:
+		return
+	}
+
+	if minNotional != nil && (!market || minNotional.ApplyToMarket) && value.LessThan(minNotional.MinNotional) {
+		report(FilterTypeMinNotional, "notional "+value.String()+" is less than "+minNotional.MinNotional.String())
+	}
+	if notional == nil {
+		return
+	}
+	if (!market || notional.ApplyMinToMarket) && value.LessThan(notional.MinNotional) {
+		report(FilterTypeNotional, "notional "+value.String()+" is less than "+notional.MinNotional.String())
+	}
+	if (!market || notional.ApplyMaxToMarket) && notional.MaxNotional.IsPositive() && value.GreaterThan(notional.MaxNotional) {
+		report(FilterTypeNotional, "notional "+value.String()+" is greater than "+notional.MaxNotional.String())
+	}
+}
+
+func (f *PriceFilter) check(price decimal.Decimal) string {
+	switch {
+	case !price.IsPositive():
+		return price.String() + " must be positive"
+	case f.MinPrice.IsPositive() && price.LessThan(f.MinPrice):
+		return price.String() + " is less than " + f.MinPrice.String()
+	case f.MaxPrice.IsPositive() && price.GreaterThan(f.MaxPrice):
+		return price.String() + " is greater than " + f.MaxPrice.String()
+	case f.TickSize.IsPositive() && !price.Sub(f.MinPrice).Mod(f.TickSize).IsZero():
+		return price.String() + " is not a multiple of tick size " + f.TickSize.String()
+	}
+
+	return ""
+}
+
+func (f *LotSizeFilter) check(qty decimal.Decimal) string {
+	switch {
+	case !qty.IsPositive():
+		return qty.String() + " must be positive"
+	case qty.LessThan(f.MinQty):
+		return qty.String() + " is less than " + f.MinQty.String()
+	case f.MaxQty.IsPositive() && qty.GreaterThan(f.MaxQty):
+		return qty.String() + " is greater than " + f.MaxQty.String()
+	case f.StepSize.IsPositive() && !qty.Sub(f.MinQty).Mod(f.StepSize).IsZero():
+		return qty.String() + " is not a multiple of step size " + f.StepSize.String()
+	}
+
+	return ""
+}
+
+func (f *TrailingDeltaFilter) check(req *OrderReq) string {
+	minDelta, maxDelta := f.MinTrailingBelowDelta, f.MaxTrailingBelowDelta
+	switch req.Type { //nolint:exhaustive
+	case OrderTypeStopLoss, OrderTypeStopLossLimit:
+		if req.Side == OrderSideBuy {
+			minDelta, maxDelta = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+		}
+	case OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		if req.Side == OrderSideSell {
+			minDelta, maxDelta = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+		}
+	}
+	delta := strconv.FormatInt(req.TrailingDelta, 10)
+	switch {
+	case req.TrailingDelta < int64(minDelta):
+		return "trailing delta " + delta + " is less than " + strconv.Itoa(minDelta)
+	case maxDelta > 0 && req.TrailingDelta > int64(maxDelta):
+		return "trailing delta " + delta + " is greater than " + strconv.Itoa(maxDelta)
+	}
+
+	return ""
+}
+
+func checkPercentPrice(price, avgPrice, up, down decimal.Decimal) string {
+	if high := avgPrice.Mul(up); up.IsPositive() && price.GreaterThan(high) {
+		return "price " + price.String() + " is greater than " + high.String()
+	}
+	if low := avgPrice.Mul(down); price.LessThan(low) {
+		return "price " + price.String() + " is less than " + low.String()
+	}
+
+	return ""
+}
+
+// RoundPrice rounds the price down to the symbol tick size
+func (s *SymbolInfo) RoundPrice(price decimal.Decimal) decimal.Decimal {
+	if f := s.PriceFilter(); f != nil {
+		return roundToStep(price, f.MinPrice, f.TickSize, false)
+	}
+
+	return price
+}
+
+// RoundQuantity rounds the quantity down to the symbol step size
+func (s *SymbolInfo) RoundQuantity(qty decimal.Decimal) decimal.Decimal {
+	if f := s.LotSize(); f != nil {
+		return roundToStep(qty, f.MinQty, f.StepSize, false)
+	}
+
+	return qty
+}
+
+// FormatPrice formats the price with the number of decimal places allowed by the symbol
+func (s *SymbolInfo) FormatPrice(price decimal.Decimal) string {
+	places := int32(s.QuotePrecision)
+	if f := s.PriceFilter(); f != nil && f.TickSize.IsPositive() {
+		places = decimalPlaces(f.TickSize)
+	}
+
+	return price.RoundDown(places).StringFixed(places)
+}
+
+// FormatQuantity formats the quantity with the number of decimal places allowed by the symbol
+func (s *SymbolInfo) FormatQuantity(qty decimal.Decimal) string {
+	places := int32(s.BaseAssetPrecision)
+	if f := s.LotSize(); f != nil && f.StepSize.IsPositive() {
+		places = decimalPlaces(f.StepSize)
+	}
+
+	return qty.RoundDown(places).StringFixed(places)
+}
+
+// NormalizeOrder rounds price, stop price and quantities of the order to the symbol tick and step sizes
+// and formats them to the symbol precision. Buy prices are rounded down and sell prices up, so the normalized
+// order is never more aggressive than the requested one. Quantities are always rounded down.
+func (s *SymbolInfo) NormalizeOrder(req *OrderReq) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	v, err := parseOrderValues(req)
+	if err != nil {
+		return err
+	}
+
+	if f := s.PriceFilter(); f != nil {
+		up := req.Side == OrderSideSell
+		if req.Price != "" {
+			req.Price = s.FormatPrice(roundToStep(v.price, f.MinPrice, f.TickSize, up))
+		}
+		if req.StopPrice != "" {
+			req.StopPrice = s.FormatPrice(roundToStep(v.stopPrice, f.MinPrice, f.TickSize, up))
+		}
+	}
+	if req.Quantity != "" {
+		qty := s.RoundQuantity(v.qty)
+		if f := s.MarketLotSize(); f != nil && req.Type == OrderTypeMarket {
+			qty = roundToStep(qty, f.MinQty, f.StepSize, false)
+		}
+		req.Quantity = s.FormatQuantity(qty)
+	}
+	if req.IcebergQty != "" {
+		req.IcebergQty = s.FormatQuantity(s.RoundQuantity(v.icebergQty))
+	}
+	if req.QuoteQuantity != "" {
+		places := int32(s.QuoteAssetPrecision)
+		req.QuoteQuantity = v.quoteQty.RoundDown(places).StringFixed(places)
+	}
+
+	return nil
+}
+
+// roundToStep rounds the value to the closest multiple of step counted from base
+func roundToStep(value, base, step decimal.Decimal, up bool) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	rem := value.Sub(base).Mod(step)
+	if rem.IsZero() {
+		return value
+	}
+	if rem.IsNegative() {
+		rem = rem.Add(step)
+	}
+	value = value.Sub(rem)
+	if up {
+		value = value.Add(step)
+	}
+
+	return value
+}
+
+// decimalPlaces returns the number of significant digits after the decimal point
+func decimalPlaces(d decimal.Decimal) int32 {
+	s := d.String()
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return int32(len(s) - i - 1)
+	}
+
+	return 0
+}
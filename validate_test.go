@@ -0,0 +1,166 @@
+package binance_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/xenking/decimal"
+
+	"github.com/ugi1/binance-api"
+)
+
+func loadSymbolInfo(t *testing.T, symbol string) *binance.SymbolInfo {
+	t.Helper()
+
+	fixture, err := os.ReadFile("testdata/exchange_info.json")
+	require.NoError(t, err)
+	info := &binance.ExchangeInfo{}
+	require.NoError(t, json.Unmarshal(fixture, info))
+	for i := range info.Symbols {
+		if info.Symbols[i].Symbol == symbol {
+			return &info.Symbols[i]
+		}
+	}
+	require.FailNow(t, "symbol not found in fixture", symbol)
+
+	return nil
+}
+
+func TestValidateOrder(t *testing.T) {
+	btc := loadSymbolInfo(t, "BTCUSDT")
+	avg := decimal.NewFromInt(60000)
+
+	valid := &binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeLimit,
+		Price:    "60000.01",
+		Quantity: "0.001",
+	}
+	require.NoError(t, btc.ValidateOrder(valid, avg))
+
+	err := btc.ValidateOrder(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeLimit,
+		Price:    "1000.001",
+		Quantity: "0.0000015",
+	}, avg)
+	var verr *binance.OrderValidationError
+	require.True(t, errors.As(err, &verr))
+	require.True(t, verr.Has(binance.FilterTypePrice))
+	require.True(t, verr.Has(binance.FilterTypeLotSize))
+	require.True(t, verr.Has(binance.FilterTypeNotional))
+	require.True(t, verr.Has(binance.FilterTypePercentPriceBySide))
+	require.Len(t, verr.Violations, 4)
+
+	// market notional uses the average price only when applyMinToMarket is set
+	err = btc.ValidateOrder(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideSell,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "0.00001",
+	}, avg)
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, []binance.FilterViolation{{
+		Filter: binance.FilterTypeNotional,
+		Reason: "notional 0.6 is less than 5",
+	}}, verr.Violations)
+
+	err = btc.ValidateOrder(&binance.OrderReq{
+		Symbol:        "BTCUSDT",
+		Side:          binance.OrderSideSell,
+		Type:          binance.OrderTypeStopLossLimit,
+		Price:         "59000",
+		StopPrice:     "59000",
+		Quantity:      "0.001",
+		TrailingDelta: 5000,
+	}, decimal.Zero)
+	require.True(t, errors.As(err, &verr))
+	require.True(t, verr.Has(binance.FilterTypeTrailingDelta))
+
+	err = btc.ValidateOrder(&binance.OrderReq{
+		Symbol:     "BTCUSDT",
+		Side:       binance.OrderSideBuy,
+		Type:       binance.OrderTypeLimit,
+		Price:      "60000",
+		Quantity:   "1",
+		IcebergQty: "0.05",
+	}, decimal.Zero)
+	require.True(t, errors.As(err, &verr))
+	require.True(t, verr.Has(binance.FilterTypeIcebergParts))
+
+	require.ErrorIs(t, btc.ValidateOrder(&binance.OrderReq{Symbol: "LTCBTC"}, avg), binance.ErrSymbolMismatch)
+}
+
+func TestValidateOrderPercentPrice(t *testing.T) {
+	ltc := loadSymbolInfo(t, "LTCBTC")
+
+	err := ltc.ValidateOrder(&binance.OrderReq{
+		Symbol:   "LTCBTC",
+		Side:     binance.OrderSideSell,
+		Type:     binance.OrderTypeLimit,
+		Price:    "0.5",
+		Quantity: "1",
+	}, decimal.RequireFromString("0.001"))
+	var verr *binance.OrderValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, binance.FilterTypePercentPrice, verr.Violations[0].Filter)
+
+	err = ltc.ValidateOrder(&binance.OrderReq{
+		Symbol:   "LTCBTC",
+		Side:     binance.OrderSideSell,
+		Type:     binance.OrderTypeLimit,
+		Price:    "0.000001",
+		Quantity: "1",
+	}, decimal.Zero)
+	require.True(t, errors.As(err, &verr))
+	require.True(t, verr.Has(binance.FilterTypeMinNotional))
+}
+
+func TestNormalizeOrder(t *testing.T) {
+	btc := loadSymbolInfo(t, "BTCUSDT")
+
+	buy := &binance.OrderReq{
+		Symbol:     "BTCUSDT",
+		Side:       binance.OrderSideBuy,
+		Type:       binance.OrderTypeLimit,
+		Price:      "60000.019",
+		Quantity:   "0.0012345",
+		IcebergQty: "0.00051",
+	}
+	require.NoError(t, btc.NormalizeOrder(buy))
+	require.Equal(t, "60000.01", buy.Price)
+	require.Equal(t, "0.00123", buy.Quantity)
+	require.Equal(t, "0.00051", buy.IcebergQty)
+	require.NoError(t, btc.ValidateOrder(buy, decimal.Zero))
+
+	sell := &binance.OrderReq{
+		Symbol:    "BTCUSDT",
+		Side:      binance.OrderSideSell,
+		Type:      binance.OrderTypeStopLossLimit,
+		Price:     "60000.011",
+		StopPrice: "60000",
+		Quantity:  "1",
+	}
+	require.NoError(t, btc.NormalizeOrder(sell))
+	require.Equal(t, "60000.02", sell.Price)
+	require.Equal(t, "60000.00", sell.StopPrice)
+	require.Equal(t, "1.00000", sell.Quantity)
+
+	market := &binance.OrderReq{
+		Symbol:        "BTCUSDT",
+		Side:          binance.OrderSideBuy,
+		Type:          binance.OrderTypeMarket,
+		QuoteQuantity: "10.123456789",
+	}
+	require.NoError(t, btc.NormalizeOrder(market))
+	require.Equal(t, "10.12345678", market.QuoteQuantity)
+
+	require.Error(t, btc.NormalizeOrder(&binance.OrderReq{Price: "abc"}))
+	require.Equal(t, "0.12", btc.FormatPrice(decimal.RequireFromString("0.129")))
+	require.True(t, btc.RoundQuantity(decimal.RequireFromString("1.234567")).Equal(decimal.RequireFromString("1.23456")))
+}
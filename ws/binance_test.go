@@ -10,6 +10,7 @@ import (
 
 	"github.com/segmentio/encoding/json"
 	"github.com/stretchr/testify/suite"
+	"github.com/xenking/decimal"
 	"github.com/xenking/websocket"
 )
 
@@ -374,6 +375,20 @@ type mockedTestSuite struct {
 	listnerDone chan struct{}
 }
 
+// requireEqualJSON compares values by their json encoding,
+// since equal decimals may differ in the internal representation
+func (s *mockedTestSuite) requireEqualJSON(expected, actual interface{}) {
+	expectedJSON, err := json.Marshal(expected)
+	s.Require().NoError(err)
+	actualJSON, err := json.Marshal(actual)
+	s.Require().NoError(err)
+	s.Require().JSONEq(string(expectedJSON), string(actualJSON))
+}
+
+func mustDecimal(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
 func (s *mockedTestSuite) SetupSuite() {
 	s.mock = &mockedClient{}
 	s.api = binance.NewCustomClient(s.mock)
@@ -420,14 +435,14 @@ func (s *mockedTestSuite) TestAccountInfo_Read() {
 			EventType:    AccountUpdateEventTypeBalanceUpdate,
 			Time:         rand.Uint64(),
 			Asset:        "BTC",
-			BalanceDelta: "1",
+			BalanceDelta: mustDecimal("1"),
 		},
 		&AccountUpdateEvent{
 			Balances: []AccountBalance{
 				{
 					Asset:  "ETH",
-					Free:   "1",
-					Locked: "0.5",
+					Free:   mustDecimal("1"),
+					Locked: mustDecimal("0.5"),
 				},
 			},
 			EventType:  AccountUpdateEventTypeOutboundAccountPosition,
@@ -440,13 +455,13 @@ func (s *mockedTestSuite) TestAccountInfo_Read() {
 			Side:             "BUY",
 			OrderType:        "LIMIT",
 			TimeInForce:      "GTC",
-			OrigQty:          "1",
-			Price:            "3400",
+			OrigQty:          mustDecimal("1"),
+			Price:            mustDecimal("3400"),
 			Status:           "FILLED",
-			FilledQty:        "1",
-			TotalFilledQty:   "1",
-			FilledPrice:      "3400",
-			Commission:       "0.00001",
+			FilledQty:        mustDecimal("1"),
+			TotalFilledQty:   mustDecimal("1"),
+			FilledPrice:      mustDecimal("3400"),
+			Commission:       mustDecimal("0.00001"),
 			CommissionAsset:  "BTC",
 			Time:             rand.Uint64(),
 			TradeTime:        rand.Uint64(),
@@ -498,7 +513,7 @@ func (s *mockedTestSuite) TestAccountInfo_Read() {
 	for _, ex := range expected {
 		_, actual, err := ws.Read()
 		s.Require().NoError(err)
-		s.Require().EqualValues(ex, actual)
+		s.requireEqualJSON(ex, actual)
 	}
 
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
@@ -518,19 +533,19 @@ func (s *mockedTestSuite) TestAccountInfo_BalancesStream() {
 			EventType:    AccountUpdateEventTypeBalanceUpdate,
 			Time:         rand.Uint64(),
 			Asset:        "BTC",
-			BalanceDelta: "1",
+			BalanceDelta: mustDecimal("1"),
 		},
 		&BalanceUpdateEvent{
 			EventType:    AccountUpdateEventTypeBalanceUpdate,
 			Time:         rand.Uint64(),
 			Asset:        "ETH",
-			BalanceDelta: "1",
+			BalanceDelta: mustDecimal("1"),
 		},
 		&BalanceUpdateEvent{
 			EventType:    AccountUpdateEventTypeBalanceUpdate,
 			Time:         rand.Uint64(),
 			Asset:        "BTC",
-			BalanceDelta: "2",
+			BalanceDelta: mustDecimal("2"),
 		},
 	}
 
@@ -556,7 +571,7 @@ func (s *mockedTestSuite) TestAccountInfo_BalancesStream() {
 	for _, ex := range expected {
 		actual := <-stream
 		s.Require().NoError(err)
-		s.Require().EqualValues(ex, actual)
+		s.requireEqualJSON(ex, actual)
 	}
 
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
@@ -576,8 +591,8 @@ func (s *mockedTestSuite) TestAccountInfo_AccountStream() {
 			Balances: []AccountBalance{
 				{
 					Asset:  "ETH",
-					Free:   "1",
-					Locked: "0.5",
+					Free:   mustDecimal("1"),
+					Locked: mustDecimal("0.5"),
 				},
 			},
 			EventType:  AccountUpdateEventTypeOutboundAccountPosition,
@@ -588,8 +603,8 @@ func (s *mockedTestSuite) TestAccountInfo_AccountStream() {
 			Balances: []AccountBalance{
 				{
 					Asset:  "BTC",
-					Free:   "1",
-					Locked: "0.5",
+					Free:   mustDecimal("1"),
+					Locked: mustDecimal("0.5"),
 				},
 			},
 			EventType:  AccountUpdateEventTypeOutboundAccountPosition,
@@ -620,7 +635,7 @@ func (s *mockedTestSuite) TestAccountInfo_AccountStream() {
 	for _, ex := range expected {
 		actual := <-stream
 		s.Require().NoError(err)
-		s.Require().EqualValues(ex, actual)
+		s.requireEqualJSON(ex, actual)
 	}
 
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
@@ -642,13 +657,13 @@ func (s *mockedTestSuite) TestAccountInfo_OrdersStream() {
 			Side:             "BUY",
 			OrderType:        "LIMIT",
 			TimeInForce:      "GTC",
-			OrigQty:          "1",
-			Price:            "3400",
+			OrigQty:          mustDecimal("1"),
+			Price:            mustDecimal("3400"),
 			Status:           "FILLED",
-			FilledQty:        "1",
-			TotalFilledQty:   "1",
-			FilledPrice:      "3400",
-			Commission:       "0.00001",
+			FilledQty:        mustDecimal("1"),
+			TotalFilledQty:   mustDecimal("1"),
+			FilledPrice:      mustDecimal("3400"),
+			Commission:       mustDecimal("0.00001"),
 			CommissionAsset:  "BTC",
 			Time:             rand.Uint64(),
 			TradeTime:        rand.Uint64(),
@@ -662,13 +677,13 @@ func (s *mockedTestSuite) TestAccountInfo_OrdersStream() {
 			Side:             "BUY",
 			OrderType:        "LIMIT",
 			TimeInForce:      "GTC",
-			OrigQty:          "1",
-			Price:            "3500",
+			OrigQty:          mustDecimal("1"),
+			Price:            mustDecimal("3500"),
 			Status:           "FILLED",
-			FilledQty:        "1",
-			TotalFilledQty:   "1",
-			FilledPrice:      "3500",
-			Commission:       "0.00001",
+			FilledQty:        mustDecimal("1"),
+			TotalFilledQty:   mustDecimal("1"),
+			FilledPrice:      mustDecimal("3500"),
+			Commission:       mustDecimal("0.00001"),
 			CommissionAsset:  "BTC",
 			Time:             rand.Uint64(),
 			TradeTime:        rand.Uint64(),
@@ -682,13 +697,13 @@ func (s *mockedTestSuite) TestAccountInfo_OrdersStream() {
 			Side:             "BUY",
 			OrderType:        "LIMIT",
 			TimeInForce:      "GTC",
-			OrigQty:          "1",
-			Price:            "3600",
+			OrigQty:          mustDecimal("1"),
+			Price:            mustDecimal("3600"),
 			Status:           "FILLED",
-			FilledQty:        "1",
-			TotalFilledQty:   "1",
-			FilledPrice:      "3600",
-			Commission:       "0.00001",
+			FilledQty:        mustDecimal("1"),
+			TotalFilledQty:   mustDecimal("1"),
+			FilledPrice:      mustDecimal("3600"),
+			Commission:       mustDecimal("0.00001"),
 			CommissionAsset:  "BTC",
 			Time:             rand.Uint64(),
 			TradeTime:        rand.Uint64(),
@@ -720,7 +735,7 @@ func (s *mockedTestSuite) TestAccountInfo_OrdersStream() {
 	for _, ex := range expected {
 		actual := <-stream
 		s.Require().NoError(err)
-		s.Require().EqualValues(ex, actual)
+		s.requireEqualJSON(ex, actual)
 	}
 
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
@@ -802,7 +817,7 @@ func (s *mockedTestSuite) TestAccountInfo_OCOOrdersStream() {
 	for _, ex := range expected {
 		actual := <-stream
 		s.Require().NoError(err)
-		s.Require().EqualValues(ex, actual)
+		s.requireEqualJSON(ex, actual)
 	}
 
 	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
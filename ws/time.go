@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/ugi1/binance-api"
+)
+
+// EventTime returns the event time as time.Time
+func (u *IndivTickerUpdate) EventTime() time.Time {
+	return binance.MsToTime(u.Time)
+}
+
+// EventTime returns the event time as time.Time
+func (u *IndivMiniTickerUpdate) EventTime() time.Time {
+	return binance.MsToTime(u.Time)
+}
+
+// EventTime returns the event time as time.Time
+func (u *DepthUpdate) EventTime() time.Time {
+	return binance.MsToTime(u.Time)
+}
+
+// EventTime returns the event time as time.Time
+func (u *KlinesUpdate) EventTime() time.Time {
+	return binance.MsToTime(u.Time)
+}
+
+// EventTime returns the event time as time.Time
+func (u *AggTradeUpdate) EventTime() time.Time {
+	return binance.MsToTime(u.Time)
+}
+
+// TradedAt returns the trade time as time.Time
+func (u *AggTradeUpdate) TradedAt() time.Time {
+	return binance.MsToTime(u.TradeTime)
+}
+
+// EventTime returns the event time as time.Time
+func (u *TradeUpdate) EventTime() time.Time {
+	return binance.MsToTime(u.Time)
+}
+
+// TradedAt returns the trade time as time.Time
+func (u *TradeUpdate) TradedAt() time.Time {
+	return binance.MsToTime(u.TradeTime)
+}
+
+// EventTime returns the event time as time.Time
+func (e *AccountUpdateEvent) EventTime() time.Time {
+	return binance.MsToTime(e.Time)
+}
+
+// EventTime returns the event time as time.Time
+func (e *BalanceUpdateEvent) EventTime() time.Time {
+	return binance.MsToTime(e.Time)
+}
+
+// EventTime returns the event time as time.Time
+func (e *OrderUpdateEvent) EventTime() time.Time {
+	return binance.MsToTime(e.Time)
+}
+
+// TradedAt returns the trade time as time.Time
+func (e *OrderUpdateEvent) TradedAt() time.Time {
+	return binance.MsToTime(e.TradeTime)
+}
+
+// EventTime returns the event time as time.Time
+func (e *OCOOrderUpdateEvent) EventTime() time.Time {
+	return binance.MsToTime(e.Time)
+}
@@ -2,8 +2,9 @@ package ws
 
 import (
 	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
 
-	"github.com/xenking/binance-api"
+	"github.com/ugi1/binance-api"
 )
 
 // UpdateType represents type of account update event
@@ -50,29 +51,29 @@ const (
 
 // IndivTickerUpdate represents incoming ticker websocket feed
 type IndivTickerUpdate struct {
-	EventType     UpdateType `json:"e"` // EventType represents the update type
-	Time          uint64     `json:"E"` // Time represents the event time
-	Symbol        string     `json:"s"` // Symbol represents the symbol related to the update
-	Price         string     `json:"p"` // Price is the order price
-	PricePercent  string     `json:"P"` // Price percent change
-	WeightedPrice string     `json:"w"` // Weighted average price
-	FirstTrade    string     `json:"x"` // First trade(F)-1 price (first trade before the 24hr rolling window)
-	LastPrice     string     `json:"c"` // Last price
-	LastQty       string     `json:"Q"` // Last quantity
-	BestBidPrice  string     `json:"b"` // Best bid price
-	BestBidQty    string     `json:"B"` // Best bid quantity
-	BestAskPrice  string     `json:"a"` // Best ask price
-	BestAskQty    string     `json:"A"` // Best ask quantity
-	OpenPrice     string     `json:"o"` // Open price
-	HighPrice     string     `json:"h"` // High price
-	LowPrice      string     `json:"l"` // Low price
-	VolumeBase    string     `json:"v"` // Total traded base asset volume
-	VolumeQuote   string     `json:"q"` // Total traded quote asset volume
-	StatisticOT   uint64     `json:"O"` // Statistics open time
-	StatisticsCT  uint64     `json:"C"` // Statistics close time
-	FirstTradeID  int64      `json:"F"` // First trade ID
-	LastTradeID   int64      `json:"L"` // Last trade ID
-	TotalTrades   int        `json:"n"` // Total number of trades
+	EventType     UpdateType      `json:"e"` // EventType represents the update type
+	Time          uint64          `json:"E"` // Time represents the event time
+	Symbol        string          `json:"s"` // Symbol represents the symbol related to the update
+	Price         decimal.Decimal `json:"p"` // Price is the order price
+	PricePercent  decimal.Decimal `json:"P"` // Price percent change
+	WeightedPrice decimal.Decimal `json:"w"` // Weighted average price
+	FirstTrade    decimal.Decimal `json:"x"` // First trade(F)-1 price (first trade before the 24hr rolling window)
+	LastPrice     decimal.Decimal `json:"c"` // Last price
+	LastQty       decimal.Decimal `json:"Q"` // Last quantity
+	BestBidPrice  decimal.Decimal `json:"b"` // Best bid price
+	BestBidQty    decimal.Decimal `json:"B"` // Best bid quantity
+	BestAskPrice  decimal.Decimal `json:"a"` // Best ask price
+	BestAskQty    decimal.Decimal `json:"A"` // Best ask quantity
+	OpenPrice     decimal.Decimal `json:"o"` // Open price
+	HighPrice     decimal.Decimal `json:"h"` // High price
+	LowPrice      decimal.Decimal `json:"l"` // Low price
+	VolumeBase    decimal.Decimal `json:"v"` // Total traded base asset volume
+	VolumeQuote   decimal.Decimal `json:"q"` // Total traded quote asset volume
+	StatisticOT   uint64          `json:"O"` // Statistics open time
+	StatisticsCT  uint64          `json:"C"` // Statistics close time
+	FirstTradeID  int64           `json:"F"` // First trade ID
+	LastTradeID   int64           `json:"L"` // Last trade ID
+	TotalTrades   int             `json:"n"` // Total number of trades
 }
 
 // AllMarketTickerUpdate represents incoming ticker websocket feed for all tickers
@@ -80,12 +81,12 @@ type AllMarketTickerUpdate []IndivTickerUpdate
 
 // IndivBookTickerUpdate represents incoming book ticker websocket feed
 type IndivBookTickerUpdate struct {
-	UpdateID int    `json:"u"` // UpdateID to sync up with updateID in /ws/v3/depth
-	Symbol   string `json:"s"` // Symbol represents the symbol related to the update
-	BidPrice string `json:"b"` // BidPrice
-	BidQty   string `json:"B"` // BidQty
-	AskPrice string `json:"a"` // AskPrice
-	AskQty   string `json:"A"` // AskQty
+	UpdateID int             `json:"u"` // UpdateID to sync up with updateID in /ws/v3/depth
+	Symbol   string          `json:"s"` // Symbol represents the symbol related to the update
+	BidPrice decimal.Decimal `json:"b"` // BidPrice
+	BidQty   decimal.Decimal `json:"B"` // BidQty
+	AskPrice decimal.Decimal `json:"a"` // AskPrice
+	AskQty   decimal.Decimal `json:"A"` // AskQty
 }
 
 // AllBookTickerUpdate represents incoming ticker websocket feed for all book tickers
@@ -99,15 +100,15 @@ type CombinedBookTickerUpdate struct {
 
 // IndivMiniTickerUpdate represents incoming mini-ticker websocket feed
 type IndivMiniTickerUpdate struct {
-	EventType   UpdateType `json:"e"` // EventType represents the update type
-	Time        uint64     `json:"E"` // Time represents the event time
-	Symbol      string     `json:"s"` // Symbol represents the symbol related to the update
-	LastPrice   string     `json:"c"` // Last price
-	OpenPrice   string     `json:"o"` // Open price
-	HighPrice   string     `json:"h"` // High price
-	LowPrice    string     `json:"l"` // Low price
-	VolumeBase  string     `json:"v"` // Total traded base asset volume
-	VolumeQuote string     `json:"q"` // Total traded quote asset volume
+	EventType   UpdateType      `json:"e"` // EventType represents the update type
+	Time        uint64          `json:"E"` // Time represents the event time
+	Symbol      string          `json:"s"` // Symbol represents the symbol related to the update
+	LastPrice   decimal.Decimal `json:"c"` // Last price
+	OpenPrice   decimal.Decimal `json:"o"` // Open price
+	HighPrice   decimal.Decimal `json:"h"` // High price
+	LowPrice    decimal.Decimal `json:"l"` // Low price
+	VolumeBase  decimal.Decimal `json:"v"` // Total traded base asset volume
+	VolumeQuote decimal.Decimal `json:"q"` // Total traded quote asset volume
 }
 
 // AllMarketMiniTickerUpdate represents incoming mini-ticker websocket feed for all tickers
@@ -144,45 +145,64 @@ type KlinesUpdate struct {
 		FirstTradeID int64                 `json:"f"` // FirstTradeID is the first trade ID
 		LastTradeID  int64                 `json:"L"` // LastTradeID is the first trade ID
 
-		OpenPrice            string `json:"o"` // OpenPrice represents the open price for this bar
-		ClosePrice           string `json:"c"` // ClosePrice represents the close price for this bar
-		High                 string `json:"h"` // High represents the highest price for this bar
-		Low                  string `json:"l"` // Low represents the lowest price for this bar
-		Volume               string `json:"v"` // Volume is the trades volume for this bar
-		Trades               int    `json:"n"` // Trades is the number of conducted trades
-		Final                bool   `json:"x"` // Final indicates whether this bar is final or yet may receive updates
-		VolumeQuote          string `json:"q"` // VolumeQuote indicates the quote volume for the symbol
-		VolumeActiveBuy      string `json:"V"` // VolumeActiveBuy represents the volume of active buy
-		VolumeQuoteActiveBuy string `json:"Q"` // VolumeQuoteActiveBuy represents the quote volume of active buy
+		OpenPrice            decimal.Decimal `json:"o"` // OpenPrice represents the open price for this bar
+		ClosePrice           decimal.Decimal `json:"c"` // ClosePrice represents the close price for this bar
+		High                 decimal.Decimal `json:"h"` // High represents the highest price for this bar
+		Low                  decimal.Decimal `json:"l"` // Low represents the lowest price for this bar
+		Volume               decimal.Decimal `json:"v"` // Volume is the trades volume for this bar
+		Trades               int             `json:"n"` // Trades is the number of conducted trades
+		Final                bool            `json:"x"` // Final indicates whether this bar is final or yet may receive updates
+		VolumeQuote          decimal.Decimal `json:"q"` // VolumeQuote indicates the quote volume for the symbol
+		VolumeActiveBuy      decimal.Decimal `json:"V"` // VolumeActiveBuy represents the volume of active buy
+		VolumeQuoteActiveBuy decimal.Decimal `json:"Q"` // VolumeQuoteActiveBuy represents the quote volume of active buy
 	} `json:"k"` // Kline is the kline update
 }
 
+// Klines converts the update to REST klines, e.g. to feed binance.Resampler
+func (u *KlinesUpdate) Klines() *binance.Klines {
+	return &binance.Klines{
+		OpenTime:                 u.Kline.StartTime,
+		OpenPrice:                u.Kline.OpenPrice,
+		High:                     u.Kline.High,
+		Low:                      u.Kline.Low,
+		ClosePrice:               u.Kline.ClosePrice,
+		Volume:                   u.Kline.Volume,
+		CloseTime:                u.Kline.EndTime,
+		QuoteAssetVolume:         u.Kline.VolumeQuote,
+		Trades:                   u.Kline.Trades,
+		TakerBuyBaseAssetVolume:  u.Kline.VolumeActiveBuy,
+		TakerBuyQuoteAssetVolume: u.Kline.VolumeQuoteActiveBuy,
+	}
+}
+
 // AggTradeUpdate represents the incoming messages for aggregated trades websocket updates
 type AggTradeUpdate struct {
-	EventType             UpdateType `json:"e"` // EventType represents the update type
-	Time                  uint64     `json:"E"` // Time represents the event time
-	Symbol                string     `json:"s"` // Symbol represents the symbol related to the update
-	TradeID               int64      `json:"a"` // TradeID is the aggregated trade ID
-	Price                 string     `json:"p"` // Price is the trade price
-	Quantity              string     `json:"q"` // Quantity is the trade quantity
-	FirstBreakDownTradeID int64      `json:"f"` // FirstBreakDownTradeID is the first breakdown trade ID
-	LastBreakDownTradeID  int64      `json:"l"` // LastBreakDownTradeID is the last breakdown trade ID
-	TradeTime             uint64     `json:"T"` // Time is the trade time
-	Maker                 bool       `json:"m"` // Maker indicates whether buyer is a maker
+	EventType             UpdateType      `json:"e"` // EventType represents the update type
+	Time                  uint64          `json:"E"` // Time represents the event time
+	Symbol                string          `json:"s"` // Symbol represents the symbol related to the update
+	TradeID               int64           `json:"a"` // TradeID is the aggregated trade ID
+	Price                 decimal.Decimal `json:"p"` // Price is the trade price
+	Quantity              decimal.Decimal `json:"q"` // Quantity is the trade quantity
+	FirstBreakDownTradeID int64           `json:"f"` // FirstBreakDownTradeID is the first breakdown trade ID
+	LastBreakDownTradeID  int64           `json:"l"` // LastBreakDownTradeID is the last breakdown trade ID
+	TradeTime             uint64          `json:"T"` // Time is the trade time
+	Maker                 bool            `json:"m"` // Maker indicates whether buyer is a maker
+	BestMatch             bool            `json:"M"` // BestMatch is ignored by Binance, it keeps "M" from being decoded into Maker
 }
 
 // TradeUpdate represents the incoming messages for trades websocket updates
 type TradeUpdate struct {
-	EventType UpdateType `json:"e"` // EventType represents the update type
-	Symbol    string     `json:"s"` // Symbol represents the symbol related to the update
-	Price     string     `json:"p"` // Price is the trade price
-	Quantity  string     `json:"q"` // Quantity is the trade quantity
-	Time      uint64     `json:"E"` // Time represents the event time
-	TradeTime uint64     `json:"T"` // Time is the trade time
-	TradeID   int64      `json:"t"` // TradeID is the aggregated trade ID
-	BuyerID   int        `json:"b"` // BuyerID is the buyer trade ID
-	SellerID  int        `json:"a"` // SellerID is the seller trade ID
-	Maker     bool       `json:"m"` // Maker indicates whether buyer is a maker
+	EventType UpdateType      `json:"e"` // EventType represents the update type
+	Symbol    string          `json:"s"` // Symbol represents the symbol related to the update
+	Price     decimal.Decimal `json:"p"` // Price is the trade price
+	Quantity  decimal.Decimal `json:"q"` // Quantity is the trade quantity
+	Time      uint64          `json:"E"` // Time represents the event time
+	TradeTime uint64          `json:"T"` // Time is the trade time
+	TradeID   int64           `json:"t"` // TradeID is the aggregated trade ID
+	BuyerID   int             `json:"b"` // BuyerID is the buyer trade ID
+	SellerID  int             `json:"a"` // SellerID is the seller trade ID
+	Maker     bool            `json:"m"` // Maker indicates whether buyer is a maker
+	BestMatch bool            `json:"M"` // BestMatch is ignored by Binance, it keeps "M" from being decoded into Maker
 }
 
 // ErrIncorrectAccountEventType represents error when event type can't before determined
@@ -224,16 +244,16 @@ type AccountUpdateEvent struct {
 }
 
 type AccountBalance struct {
-	Asset  string `json:"a"`
-	Free   string `json:"f"`
-	Locked string `json:"l"`
+	Asset  string          `json:"a"`
+	Free   decimal.Decimal `json:"f"`
+	Locked decimal.Decimal `json:"l"`
 }
 
 // BalanceUpdateEvent represents the incoming message for account balances websocket updates
 type BalanceUpdateEvent struct {
 	EventType    AccountUpdateEventType `json:"e"` // EventType represents the update type
 	Asset        string                 `json:"a"` // Asset
-	BalanceDelta string                 `json:"d"` // Balance Delta
+	BalanceDelta decimal.Decimal        `json:"d"` // Balance Delta
 	Time         uint64                 `json:"E"` // Time represents the event time
 	ClearTime    uint64                 `json:"T"` // Clear Time
 }
@@ -246,22 +266,22 @@ type OrderUpdateEvent struct {
 	Side                binance.OrderSide      `json:"S"` // Side is the order side
 	OrderType           binance.OrderType      `json:"o"` // OrderType represents the order type
 	TimeInForce         binance.TimeInForce    `json:"f"` // TimeInForce represents the order TIF type
-	OrigQty             string                 `json:"q"` // OrigQty represents the order original quantity
-	Price               string                 `json:"p"` // Price is the order price
-	StopPrice           string                 `json:"P"`
-	IcebergQty          string                 `json:"F"`
+	OrigQty             decimal.Decimal        `json:"q"` // OrigQty represents the order original quantity
+	Price               decimal.Decimal        `json:"p"` // Price is the order price
+	StopPrice           decimal.Decimal        `json:"P"`
+	IcebergQty          decimal.Decimal        `json:"F"`
 	OrigClientOrderID   string                 `json:"C"`
 	ExecutionType       binance.OrderStatus    `json:"x"` // ExecutionType represents the execution type for the order
 	Status              binance.OrderStatus    `json:"X"` // Status represents the order status for the order
 	Error               binance.OrderFailure   `json:"r"` // Error represents an order rejection reason
-	FilledQty           string                 `json:"l"` // FilledQty represents the quantity of the last filled trade
-	TotalFilledQty      string                 `json:"z"` // TotalFilledQty is the accumulated quantity of filled trades on this order
-	FilledPrice         string                 `json:"L"` // FilledPrice is the price of last filled trade
-	Commission          string                 `json:"n"` // Commission is the commission for the trade
+	FilledQty           decimal.Decimal        `json:"l"` // FilledQty represents the quantity of the last filled trade
+	TotalFilledQty      decimal.Decimal        `json:"z"` // TotalFilledQty is the accumulated quantity of filled trades on this order
+	FilledPrice         decimal.Decimal        `json:"L"` // FilledPrice is the price of last filled trade
+	Commission          decimal.Decimal        `json:"n"` // Commission is the commission for the trade
 	CommissionAsset     string                 `json:"N"` // CommissionAsset is the asset on which commission is taken
-	QuoteTotalFilledQty string                 `json:"Z"` // Cumulative quote asset transacted quantity
-	QuoteFilledQty      string                 `json:"Y"` // Last quote asset transacted quantity (i.e. lastPrice * lastQty)
-	QuoteQty            string                 `json:"Q"` // Quote Order Qty
+	QuoteTotalFilledQty decimal.Decimal        `json:"Z"` // Cumulative quote asset transacted quantity
+	QuoteFilledQty      decimal.Decimal        `json:"Y"` // Last quote asset transacted quantity (i.e. lastPrice * lastQty)
+	QuoteQty            decimal.Decimal        `json:"Q"` // Quote Order Qty
 	Time                uint64                 `json:"E"` // Time represents the event time
 	TradeTime           uint64                 `json:"T"` // TradeTime is the trade time
 	OrderCreatedTime    uint64                 `json:"O"` // OrderTime represents the order time
@@ -271,15 +291,31 @@ type OrderUpdateEvent struct {
 	StrategyID          int                    `json:"j"` // Strategy ID; This is only visible if the strategyId parameter was provided upon order placement
 	StrategyType        int                    `json:"J"` // Strategy Type; This is only visible if the strategyType parameter was provided upon order placement
 	Maker               bool                   `json:"m"` // Maker represents whether buyer is maker or not
+	BestMatch           bool                   `json:"M"` // BestMatch is ignored by Binance, it keeps "M" from being decoded into Maker
+	Ignored             int64                  `json:"I"` // Ignored by Binance, it keeps "I" from being decoded into OrderID
+
+	SelfTradePreventionMode binance.SelfTradePreventionMode `json:"V"`
+	PreventedMatchID        int64                           `json:"v"` // Only visible if the order expired due to STP
+	PreventedQty            decimal.Decimal                 `json:"A"` // Total prevented quantity of the order
+	LastPreventedQty        decimal.Decimal                 `json:"B"` // Prevented quantity of the last match
+	TradeGroupID            int64                           `json:"u"`
+	CounterOrderID          uint64                          `json:"U"`
+	CounterSymbol           string                          `json:"Cs"`
+}
+
+// Amended reports whether the update is caused by the order amendment with keep priority.
+// Amended order has the new quantity in OrigQty
+func (e *OrderUpdateEvent) Amended() bool {
+	return e.ExecutionType == binance.OrderStatusReplaced
 }
 
 type OCOOrderUpdateEvent struct {
 	EventType         AccountUpdateEventType     `json:"e"`
 	Orders            []OCOOrderUpdateEventOrder `json:"O"`
 	Symbol            string                     `json:"s"`
-	ContingencyType   string                     `json:"c"`
-	ListStatusType    string                     `json:"l"`
-	ListOrderStatus   string                     `json:"L"`
+	ContingencyType   binance.ContingencyType    `json:"c"`
+	ListStatusType    binance.ListStatusType     `json:"l"`
+	ListOrderStatus   binance.ListOrderStatus    `json:"L"`
 	ListRejectReason  binance.OrderFailure       `json:"r"`
 	ListClientOrderID string                     `json:"C"`
 	TransactTime      uint64                     `json:"T"`
@@ -0,0 +1,38 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ugi1/binance-api"
+)
+
+func TestOrderUpdateEventDecode(t *testing.T) {
+	raw := `{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT",
+		"f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000","g":-1,"C":"","x":"TRADE_PREVENTION",
+		"X":"EXPIRED","r":"NONE","i":4293153,"l":"0.00000000","z":"0.00000000","L":"0.00000000","n":"0","N":null,
+		"T":1499405658657,"t":-1,"v":3,"I":8641984,"w":false,"m":false,"M":true,"O":1499405658657,"Z":"0.00000000",
+		"Y":"0.00000000","Q":"0.00000000","W":1499405658657,"V":"EXPIRE_MAKER","A":"1.00000000","B":"1.00000000",
+		"u":1,"U":37,"Cs":"ETHBTC"}`
+	e := &OrderUpdateEvent{}
+	require.NoError(t, json.Unmarshal([]byte(raw), e))
+	require.EqualValues(t, 4293153, e.OrderID)
+	require.False(t, e.Maker)
+	require.Equal(t, binance.OrderStatusTradePrevention, e.ExecutionType)
+	require.Equal(t, binance.SelfTradePreventionModeExpireMaker, e.SelfTradePreventionMode)
+	require.EqualValues(t, 3, e.PreventedMatchID)
+	require.Equal(t, "1.00000000", binance.FormatDecimal(e.PreventedQty))
+	require.EqualValues(t, 37, e.CounterOrderID)
+	require.Equal(t, "ETHBTC", e.CounterSymbol)
+	require.False(t, e.Amended())
+	require.EqualValues(t, 1499405658658, binance.TimeToMs(e.EventTime()))
+	require.EqualValues(t, 1499405658657, binance.TimeToMs(e.TradedAt()))
+
+	trade := &TradeUpdate{}
+	require.NoError(t, json.Unmarshal([]byte(`{"e":"trade","t":12345,"p":"0.001","q":"100","T":123456785,"m":false,"M":true}`), trade))
+	require.False(t, trade.Maker)
+	require.EqualValues(t, 123456785, binance.TimeToMs(trade.TradedAt()))
+	require.True(t, trade.EventTime().IsZero())
+}